@@ -3,7 +3,10 @@ package cfg
 import (
 	"errors"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,33 +14,130 @@ import (
 type RedisConfig struct {
 	Host string
 	Port string
+	// CompressThresholdBytes gzips cached values from this size, 0 disables compression
+	CompressThresholdBytes int
+
+	// Optional, only needed for managed Redis. Zero values keep the go-redis defaults.
+	Username       string
+	Password       string
+	DB             int
+	TLSEnabled     bool
+	TLSSkipVerify  bool
+	PoolSize       int
+	DialTimeoutMs  int
+	ReadTimeoutMs  int
+	WriteTimeoutMs int
+}
+
+type LocalCacheConfig struct {
+	Size       int
+	TTLSeconds int
+}
+
+// AncillaryFeeConfig is the estimated per passenger seat and baggage fee for a cabin class
+type AncillaryFeeConfig struct {
+	Seat    uint64
+	Baggage uint64
 }
 
 type AirAsiaClientConfig struct {
 	BaseURL string
+	// RouteAirports are the IATA codes the provider flies to, empty means every route
+	RouteAirports []string
+	// RateLimitQPS caps outbound calls per second, 0 means no limit
+	RateLimitQPS   int
+	RateLimitBurst int
 }
 
 type BatikAirClientConfig struct {
 	BaseURL string
+	// RouteAirports are the IATA codes the provider flies to, empty means every route
+	RouteAirports []string
+	// RateLimitQPS caps outbound calls per second, 0 means no limit
+	RateLimitQPS   int
+	RateLimitBurst int
 }
 
 type GarudaIndonesiaClientConfig struct {
 	BaseURL string
+	// RouteAirports are the IATA codes the provider flies to, empty means every route
+	RouteAirports []string
+	// RateLimitQPS caps outbound calls per second, 0 means no limit
+	RateLimitQPS   int
+	RateLimitBurst int
 }
 
 type LionAirClientConfig struct {
 	BaseURL string
+	// RouteAirports are the IATA codes the provider flies to, empty means every route
+	RouteAirports []string
+	// RateLimitQPS caps outbound calls per second, 0 means no limit
+	RateLimitQPS   int
+	RateLimitBurst int
+}
+
+// CORSConfig controls which browser origins may call the API, no AllowedOrigins turns CORS off
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	MaxAgeSeconds    int
+	AllowCredentials bool
+}
+
+// SLOConfig is the search latency objective reported by /slo
+type SLOConfig struct {
+	TargetMs      int
+	WindowSeconds int
+	// MaxSamples bounds the latencies kept per endpoint
+	MaxSamples int
+}
+
+// RouteTimeoutConfig caps how long each route group may take before answering 504
+type RouteTimeoutConfig struct {
+	// FlightsMs covers search, filter and multicity, which fan out to every provider
+	FlightsMs   int
+	ReadinessMs int
+	// DefaultMs covers everything else: admin, slo and docs
+	DefaultMs int
 }
 
 type Config struct {
 	AppEnv               string
 	AppPort              string
 	RedisConfig          RedisConfig
+	LocalCacheConfig     LocalCacheConfig
 	AirAsiaClientConfig  AirAsiaClientConfig
 	BatikAirClientConfig BatikAirClientConfig
 	GarudaClientConfig   GarudaIndonesiaClientConfig
 	LionAirClientConfig  LionAirClientConfig
 	CacheTTLSeconds      int
+	// RouteCacheTTLSeconds overrides CacheTTLSeconds per route, keyed "ORIGIN-DESTINATION"
+	RouteCacheTTLSeconds map[string]int
+	AncillaryFees        map[string]AncillaryFeeConfig
+	SLOConfig            SLOConfig
+	CORSConfig           CORSConfig
+	RouteTimeoutConfig   RouteTimeoutConfig
+	// AdminSecret protects the /admin endpoints, sent as X-Admin-Secret
+	AdminSecret string
+	// MaxBodyBytes is the largest request body accepted, bigger ones get a 413
+	MaxBodyBytes int64
+	// ResponseCompressMinBytes is the smallest response body that gets gzipped
+	ResponseCompressMinBytes int
+	// SupportedCurrencies are ISO-4217 codes, checked against the known list at startup. Defaults to IDR
+	SupportedCurrencies []string
+	// MultiCityMaxLegs caps the legs of a multi-city search
+	MultiCityMaxLegs int
+	// ProviderWeights biases weighted_best_value sorting, keyed by provider name (Flight.Provider).
+	// Every provider defaults to 1.0.
+	ProviderWeights map[string]float64
+	// ProviderCacheTTL overrides the cache TTL for responses with flights from these providers, keyed by provider name.
+	// Providers without an entry use the route or global TTL.
+	ProviderCacheTTL map[string]time.Duration
+	// ProviderRateLimitFailFast fails calls over a provider's rate limit instead of queueing them
+	ProviderRateLimitFailFast bool
+	// ProviderEmptyBodyAsError fails a provider that answers 200 with an empty body instead of returning zero flights
+	ProviderEmptyBodyAsError bool
 }
 
 func Load() (*Config, error) {
@@ -50,18 +150,80 @@ func Load() (*Config, error) {
 	appPort := mustEnv("APP_PORT", &errs)
 	redisHost := mustEnv("REDIS_HOST", &errs)
 	redistPort := mustEnv("REDIS_PORT", &errs)
+	redisCompressThreshold := optionalIntEnv("REDIS_COMPRESS_THRESHOLD_BYTES", 0, &errs)
+	redisUsername := os.Getenv("REDIS_USERNAME")
+	redisPassword := os.Getenv("REDIS_PASSWORD")
+	redisDB := optionalIntEnv("REDIS_DB", 0, &errs)
+	redisTLSEnabled := optionalBoolEnv("REDIS_TLS_ENABLED", false, &errs)
+	redisTLSSkipVerify := optionalBoolEnv("REDIS_TLS_SKIP_VERIFY", false, &errs)
+	redisPoolSize := optionalIntEnv("REDIS_POOL_SIZE", 0, &errs)
+	redisDialTimeoutMs := optionalIntEnv("REDIS_DIAL_TIMEOUT_MS", 0, &errs)
+	redisReadTimeoutMs := optionalIntEnv("REDIS_READ_TIMEOUT_MS", 0, &errs)
+	redisWriteTimeoutMs := optionalIntEnv("REDIS_WRITE_TIMEOUT_MS", 0, &errs)
 
 	airAsiaClientBaseUrl := mustEnv("AIRASIA_CLIENT_BASE_URL", &errs)
+	airAsiaRouteAirports := parseList(os.Getenv("AIRASIA_ROUTE_AIRPORTS"))
+	airAsiaRateLimitQPS := optionalIntEnv("AIRASIA_RATE_LIMIT_QPS", 0, &errs)
+	airAsiaRateLimitBurst := optionalIntEnv("AIRASIA_RATE_LIMIT_BURST", 1, &errs)
 	batikAirClientBaseUrl := mustEnv("BATIKAIR_CLIENT_BASE_URL", &errs)
+	batikAirRouteAirports := parseList(os.Getenv("BATIKAIR_ROUTE_AIRPORTS"))
+	batikAirRateLimitQPS := optionalIntEnv("BATIKAIR_RATE_LIMIT_QPS", 0, &errs)
+	batikAirRateLimitBurst := optionalIntEnv("BATIKAIR_RATE_LIMIT_BURST", 1, &errs)
 	garudaClientBaseUrl := mustEnv("GARUDA_CLIENT_BASE_URL", &errs)
+	garudaRouteAirports := parseList(os.Getenv("GARUDA_ROUTE_AIRPORTS"))
+	garudaRateLimitQPS := optionalIntEnv("GARUDA_RATE_LIMIT_QPS", 0, &errs)
+	garudaRateLimitBurst := optionalIntEnv("GARUDA_RATE_LIMIT_BURST", 1, &errs)
 	lionAirClientBaseUrl := mustEnv("LIONAIR_CLIENT_BASE_URL", &errs)
+	lionAirRouteAirports := parseList(os.Getenv("LIONAIR_ROUTE_AIRPORTS"))
+	lionAirRateLimitQPS := optionalIntEnv("LIONAIR_RATE_LIMIT_QPS", 0, &errs)
+	lionAirRateLimitBurst := optionalIntEnv("LIONAIR_RATE_LIMIT_BURST", 1, &errs)
+	providerEmptyBodyAsError := optionalBoolEnv("PROVIDER_EMPTY_BODY_AS_ERROR", false, &errs)
+	providerRateLimitFailFast := optionalBoolEnv("PROVIDER_RATE_LIMIT_FAIL_FAST", false, &errs)
 
-	cacheTTLInSeconds := mustEnv("CACHE_TTL_SECONDS", &errs)
-	cacheTTLSecondsInt, err := strconv.Atoi(cacheTTLInSeconds)
-
-	if err != nil {
-		errs = append(errs, errors.New("conversion failed env: "+"CACHE_TTL_SECONDS"))
+	cacheTTLSecondsInt := mustIntEnv("CACHE_TTL_SECONDS", &errs)
+	routeCacheTTLSeconds := parseRouteCacheTTL(os.Getenv("CACHE_TTL_ROUTE_OVERRIDES"), &errs)
+	localCacheSize := optionalPositiveIntEnv("LOCAL_CACHE_SIZE", 1000, &errs)
+	localCacheTTLSeconds := optionalPositiveIntEnv("LOCAL_CACHE_TTL_SECONDS", 10, &errs)
+	ancillaryFees := parseAncillaryFees(os.Getenv("ANCILLARY_FEES"), &errs)
+	supportedCurrencies := parseList(os.Getenv("SUPPORTED_CURRENCIES"))
+	if len(supportedCurrencies) == 0 {
+		supportedCurrencies = []string{"IDR"} // what every provider quotes in
 	}
+	responseCompressMinBytes := optionalIntEnv("RESPONSE_COMPRESS_MIN_BYTES", 1024, &errs) // smaller bodies cost more to gzip than they save
+	adminSecret := mustEnv("ADMIN_SECRET", &errs)
+	maxBodyBytes := optionalPositiveIntEnv("MAX_BODY_BYTES", 64<<10, &errs) // 64 KB is plenty for any search or filter request
+	sloTargetMs := optionalPositiveIntEnv("SLO_TARGET_MS", 2000, &errs)
+	sloWindowSeconds := optionalPositiveIntEnv("SLO_WINDOW_SECONDS", 300, &errs)
+	sloMaxSamples := optionalPositiveIntEnv("SLO_MAX_SAMPLES", 1000, &errs)
+	corsAllowedOrigins := parseList(os.Getenv("CORS_ALLOWED_ORIGINS"))
+	corsAllowedMethods := parseList(optionalEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,OPTIONS"))
+	corsAllowedHeaders := parseList(optionalEnv("CORS_ALLOWED_HEADERS", "Content-Type,Accept-Encoding,If-None-Match"))
+	corsMaxAgeSeconds := optionalIntEnv("CORS_MAX_AGE_SECONDS", 600, &errs)
+	corsAllowCredentials := optionalBoolEnv("CORS_ALLOW_CREDENTIALS", false, &errs)
+	if corsAllowCredentials && slices.Contains(corsAllowedOrigins, "*") {
+		errs = append(errs, errors.New("invalid env: CORS_ALLOW_CREDENTIALS cannot be used with CORS_ALLOWED_ORIGINS=*"))
+	}
+	multiCityMaxLegs := optionalIntEnv("MULTICITY_MAX_LEGS", 5, &errs)
+	providerWeights := map[string]float64{
+		"AirAsia":          optionalFloatEnv("AIRASIA_WEIGHT", 1.0, &errs),
+		"Batik Air":        optionalFloatEnv("BATIKAIR_WEIGHT", 1.0, &errs),
+		"Garuda Indonesia": optionalFloatEnv("GARUDA_WEIGHT", 1.0, &errs),
+		"Lion Air":         optionalFloatEnv("LIONAIR_WEIGHT", 1.0, &errs),
+	}
+	providerCacheTTL := make(map[string]time.Duration)
+	for provider, key := range map[string]string{
+		"AirAsia":          "AIRASIA_CACHE_TTL_SECONDS",
+		"Batik Air":        "BATIKAIR_CACHE_TTL_SECONDS",
+		"Garuda Indonesia": "GARUDA_CACHE_TTL_SECONDS",
+		"Lion Air":         "LIONAIR_CACHE_TTL_SECONDS",
+	} {
+		if seconds := optionalIntEnv(key, 0, &errs); seconds > 0 {
+			providerCacheTTL[provider] = time.Duration(seconds) * time.Second
+		}
+	}
+	routeTimeoutFlightsMs := optionalPositiveIntEnv("ROUTE_TIMEOUT_FLIGHTS_MS", 12000, &errs)
+	routeTimeoutReadinessMs := optionalPositiveIntEnv("ROUTE_TIMEOUT_READINESS_MS", 100, &errs)
+	routeTimeoutDefaultMs := optionalPositiveIntEnv("ROUTE_TIMEOUT_DEFAULT_MS", 2000, &errs)
 
 	if len(errs) > 0 {
 		return nil, errors.Join(errs...)
@@ -71,22 +233,78 @@ func Load() (*Config, error) {
 		AppEnv:  appEnv,
 		AppPort: appPort,
 		RedisConfig: RedisConfig{
-			Host: redisHost,
-			Port: redistPort,
+			Host:                   redisHost,
+			Port:                   redistPort,
+			CompressThresholdBytes: redisCompressThreshold,
+			Username:               redisUsername,
+			Password:               redisPassword,
+			DB:                     redisDB,
+			TLSEnabled:             redisTLSEnabled,
+			TLSSkipVerify:          redisTLSSkipVerify,
+			PoolSize:               redisPoolSize,
+			DialTimeoutMs:          redisDialTimeoutMs,
+			ReadTimeoutMs:          redisReadTimeoutMs,
+			WriteTimeoutMs:         redisWriteTimeoutMs,
+		},
+		LocalCacheConfig: LocalCacheConfig{
+			Size:       localCacheSize,
+			TTLSeconds: localCacheTTLSeconds,
 		},
 		AirAsiaClientConfig: AirAsiaClientConfig{
-			BaseURL: airAsiaClientBaseUrl,
+			BaseURL:        airAsiaClientBaseUrl,
+			RouteAirports:  airAsiaRouteAirports,
+			RateLimitQPS:   airAsiaRateLimitQPS,
+			RateLimitBurst: airAsiaRateLimitBurst,
 		},
 		BatikAirClientConfig: BatikAirClientConfig{
-			BaseURL: batikAirClientBaseUrl,
+			BaseURL:        batikAirClientBaseUrl,
+			RouteAirports:  batikAirRouteAirports,
+			RateLimitQPS:   batikAirRateLimitQPS,
+			RateLimitBurst: batikAirRateLimitBurst,
 		},
 		GarudaClientConfig: GarudaIndonesiaClientConfig{
-			BaseURL: garudaClientBaseUrl,
+			BaseURL:        garudaClientBaseUrl,
+			RouteAirports:  garudaRouteAirports,
+			RateLimitQPS:   garudaRateLimitQPS,
+			RateLimitBurst: garudaRateLimitBurst,
 		},
 		LionAirClientConfig: LionAirClientConfig{
-			BaseURL: lionAirClientBaseUrl,
+			BaseURL:        lionAirClientBaseUrl,
+			RouteAirports:  lionAirRouteAirports,
+			RateLimitQPS:   lionAirRateLimitQPS,
+			RateLimitBurst: lionAirRateLimitBurst,
 		},
-		CacheTTLSeconds: cacheTTLSecondsInt,
+		CacheTTLSeconds:      cacheTTLSecondsInt,
+		RouteCacheTTLSeconds: routeCacheTTLSeconds,
+		AncillaryFees:        ancillaryFees,
+
+		SupportedCurrencies:      supportedCurrencies,
+		ResponseCompressMinBytes: responseCompressMinBytes,
+		AdminSecret:              adminSecret,
+		MaxBodyBytes:             int64(maxBodyBytes),
+		SLOConfig: SLOConfig{
+			TargetMs:      sloTargetMs,
+			WindowSeconds: sloWindowSeconds,
+			MaxSamples:    sloMaxSamples,
+		},
+		CORSConfig: CORSConfig{
+			AllowedOrigins:   corsAllowedOrigins,
+			AllowedMethods:   corsAllowedMethods,
+			AllowedHeaders:   corsAllowedHeaders,
+			MaxAgeSeconds:    corsMaxAgeSeconds,
+			AllowCredentials: corsAllowCredentials,
+		},
+		RouteTimeoutConfig: RouteTimeoutConfig{
+			FlightsMs:   routeTimeoutFlightsMs,
+			ReadinessMs: routeTimeoutReadinessMs,
+			DefaultMs:   routeTimeoutDefaultMs,
+		},
+
+		ProviderEmptyBodyAsError:  providerEmptyBodyAsError,
+		ProviderRateLimitFailFast: providerRateLimitFailFast,
+		ProviderWeights:           providerWeights,
+		ProviderCacheTTL:          providerCacheTTL,
+		MultiCityMaxLegs:          multiCityMaxLegs,
 	}, nil
 }
 
@@ -97,3 +315,127 @@ func mustEnv(key string, errs *[]error) string {
 	}
 	return value
 }
+
+// optionalEnv returns def when the env is not set
+func optionalEnv(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+func mustIntEnv(key string, errs *[]error) int {
+	value := mustEnv(key, errs)
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		*errs = append(*errs, errors.New("conversion failed env: "+key))
+	}
+	return n
+}
+
+// optionalIntEnv returns def when the env is not set, but still rejects values that are not numbers
+func optionalIntEnv(key string, def int, errs *[]error) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		*errs = append(*errs, errors.New("conversion failed env: "+key))
+	}
+	return n
+}
+
+// optionalPositiveIntEnv is optionalIntEnv for sizes and durations, where 0 or less is a mistake
+func optionalPositiveIntEnv(key string, def int, errs *[]error) int {
+	n := optionalIntEnv(key, def, errs)
+	if n <= 0 {
+		*errs = append(*errs, errors.New("env must be positive: "+key))
+	}
+	return n
+}
+
+// optionalFloatEnv returns def when the env is not set, but still rejects values that are not numbers
+func optionalFloatEnv(key string, def float64, errs *[]error) float64 {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		*errs = append(*errs, errors.New("conversion failed env: "+key))
+	}
+	return f
+}
+
+func optionalBoolEnv(key string, def bool, errs *[]error) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		*errs = append(*errs, errors.New("conversion failed env: "+key))
+	}
+	return b
+}
+
+// parseList splits a comma separated env value, dropping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseAncillaryFees reads "cabin:seat:baggage" entries separated by commas,
+// e.g. "economy:50000:150000,business:0:0"
+func parseAncillaryFees(value string, errs *[]error) map[string]AncillaryFeeConfig {
+	fees := make(map[string]AncillaryFeeConfig)
+	if value == "" {
+		return fees
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 {
+			*errs = append(*errs, errors.New("invalid entry in env ANCILLARY_FEES: "+entry))
+			continue
+		}
+		seat, errSeat := strconv.ParseUint(parts[1], 10, 64)
+		baggage, errBaggage := strconv.ParseUint(parts[2], 10, 64)
+		if errSeat != nil || errBaggage != nil {
+			*errs = append(*errs, errors.New("conversion failed env: ANCILLARY_FEES entry "+entry))
+			continue
+		}
+		fees[parts[0]] = AncillaryFeeConfig{Seat: seat, Baggage: baggage}
+	}
+	return fees
+}
+
+// parseRouteCacheTTL reads "ORIGIN-DESTINATION:seconds" entries separated by commas,
+// e.g. "CGK-SIN:3600,CGK-DPS:60"
+func parseRouteCacheTTL(value string, errs *[]error) map[string]int {
+	ttls := make(map[string]int)
+	for _, entry := range parseList(value) {
+		route, secondsStr, ok := strings.Cut(entry, ":")
+		origin, destination, okRoute := strings.Cut(route, "-")
+		if !ok || !okRoute || len(origin) != 3 || len(destination) != 3 {
+			*errs = append(*errs, errors.New("invalid entry in env CACHE_TTL_ROUTE_OVERRIDES: "+entry))
+			continue
+		}
+		seconds, err := strconv.Atoi(secondsStr)
+		if err != nil || seconds <= 0 {
+			*errs = append(*errs, errors.New("conversion failed env: CACHE_TTL_ROUTE_OVERRIDES entry "+entry))
+			continue
+		}
+		ttls[strings.ToUpper(route)] = seconds
+	}
+	return ttls
+}
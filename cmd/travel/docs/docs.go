@@ -15,6 +15,213 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/loglevel": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the current log level",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin secret",
+                        "name": "X-Admin-Secret",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Change the log level at runtime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin secret",
+                        "name": "X-Admin-Secret",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New level (debug, info, warn, error)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.LogLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/slo": {
+            "get": {
+                "description": "P50/P95/P99 and the share of requests within the latency target, per endpoint, over the rolling window",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "observability"
+                ],
+                "summary": "Latency SLO report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/slo.SLOResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/currencies": {
+            "get": {
+                "description": "ISO-4217 currencies prices can be quoted in, with symbol and decimal places",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reference"
+                ],
+                "summary": "List supported currencies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/flight.Currency"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flights/cache/invalidate": {
+            "post": {
+                "description": "Drop the cached search result for a route so the next search hits the providers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Invalidate cached flight results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin secret",
+                        "name": "X-Admin-Secret",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Search Criteria",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/flight.SearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/v1/flights/filter": {
             "post": {
                 "description": "Apply filters like price range, airline, or transit",
@@ -35,8 +242,18 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_flight.FilterRequest"
+                            "$ref": "#/definitions/flight.FilterRequest"
                         }
+                    },
+                    {
+                        "enum": [
+                            "native",
+                            "offer"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -59,9 +276,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/flights/search": {
+        "/v1/flights/multicity": {
             "post": {
-                "description": "Search flights based on origin, destination, and dates",
+                "description": "Search every leg (2 to 5) in parallel, each leg is cached on its own",
                 "consumes": [
                     "application/json"
                 ],
@@ -71,24 +288,23 @@ const docTemplate = `{
                 "tags": [
                     "flights"
                 ],
-                "summary": "Search for flights",
+                "summary": "Search a one-way multi-city itinerary",
                 "parameters": [
                     {
-                        "description": "Flight Search Criteria",
+                        "description": "Itinerary Legs",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_flight.SearchRequest"
+                            "$ref": "#/definitions/flight.MultiCityRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Replace this with your actual Response Struct",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/flight.MultiCityResponse"
                         }
                     },
                     "400": {
@@ -99,9 +315,79 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/v1/flights/providers/health": {
+            "get": {
+                "description": "Probe every provider in parallel within 2 seconds. Always 200, each entry says whether that provider is healthy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "flights"
+                ],
+                "summary": "Check airline provider health",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/flight.ProviderHealth"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flights/search": {
+            "post": {
+                "description": "Query all providers in parallel, results are cached per search",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "flights"
+                ],
+                "summary": "Search flights across every airline provider",
+                "parameters": [
+                    {
+                        "description": "Search Criteria",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/flight.SearchRequest"
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    {
+                        "enum": [
+                            "native",
+                            "offer"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/flight.FlightSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -114,7 +400,29 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "internal_flight.ArrivalTime": {
+        "admin.LogLevelRequest": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.Airline": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.ArrivalTime": {
             "type": "object",
             "properties": {
                 "from": {
@@ -125,7 +433,32 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_flight.DepartureTime": {
+        "flight.Baggage": {
+            "type": "object",
+            "properties": {
+                "carry_on": {
+                    "type": "string"
+                },
+                "checked": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "decimal_places": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.DepartureTime": {
             "type": "object",
             "properties": {
                 "from": {
@@ -136,7 +469,59 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_flight.FilterOptions": {
+        "flight.Duration": {
+            "type": "object",
+            "properties": {
+                "formatted": {
+                    "type": "string"
+                },
+                "total_minutes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "TIMEOUT",
+                "INTERNAL_FAILURE",
+                "CACHE_FAILURE",
+                "NOT_FOUND",
+                "VALIDATION_ERROR",
+                "INVALID_DATE_FORMAT",
+                "DEPARTURE_IN_PAST",
+                "RETURN_BEFORE_DEPARTURE",
+                "INVALID_PASSENGER_COUNT",
+                "SAME_ORIGIN_DESTINATION",
+                "INVALID_ITINERARY",
+                "LEG_DATES_OUT_OF_ORDER",
+                "TOO_MANY_LEGS",
+                "INVALID_CURRENCY",
+                "UNSUPPORTED_CURRENCY",
+                "PROVIDER_FAILURE",
+                "RATE_LIMITED"
+            ],
+            "x-enum-varnames": [
+                "ErrorCodeTimeout",
+                "ErrorCodeInternalFailure",
+                "ErrorCodeCacheFailure",
+                "ErrorCodeNotFound",
+                "ErrorCodeValidation",
+                "ErrorCodeInvalidDateFormat",
+                "ErrorCodeDeparturePast",
+                "ErrorCodeReturnBeforeDeparture",
+                "ErrorCodeInvalidPassengerCount",
+                "ErrorCodeSameOriginDestination",
+                "ErrorCodeInvalidItinerary",
+                "ErrorCodeLegDatesOutOfOrder",
+                "ErrorCodeTooManyLegs",
+                "ErrorCodeInvalidCurrency",
+                "ErrorCodeUnsupportedCurrency",
+                "ErrorCodeProviderFailed",
+                "ErrorCodeRateLimited"
+            ]
+        },
+        "flight.FilterOptions": {
             "type": "object",
             "properties": {
                 "airlines": {
@@ -146,10 +531,10 @@ const docTemplate = `{
                     }
                 },
                 "arrival_time": {
-                    "$ref": "#/definitions/internal_flight.ArrivalTime"
+                    "$ref": "#/definitions/flight.ArrivalTime"
                 },
                 "departure_time": {
-                    "$ref": "#/definitions/internal_flight.DepartureTime"
+                    "$ref": "#/definitions/flight.DepartureTime"
                 },
                 "max_duration": {
                     "type": "integer"
@@ -158,11 +543,15 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "price_range": {
-                    "$ref": "#/definitions/internal_flight.PriceRange"
+                    "$ref": "#/definitions/flight.PriceRange"
+                },
+                "seats_together": {
+                    "description": "SeatsTogether keeps only flights that can seat a group of this size next to each other.\nSearches use it through /v1/flights/filter, whose body is the search request plus filters.",
+                    "type": "integer"
                 }
             }
         },
-        "internal_flight.FilterRequest": {
+        "flight.FilterRequest": {
             "type": "object",
             "properties": {
                 "cabin_class": {
@@ -175,11 +564,14 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "filters": {
-                    "$ref": "#/definitions/internal_flight.FilterOptions"
+                    "$ref": "#/definitions/flight.FilterOptions"
                 },
                 "origin": {
                     "type": "string"
                 },
+                "page": {
+                    "$ref": "#/definitions/flight.PageRequest"
+                },
                 "passengers": {
                     "type": "integer"
                 },
@@ -187,11 +579,233 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "sort": {
-                    "$ref": "#/definitions/internal_flight.SortOptions"
+                    "$ref": "#/definitions/flight.SortOptions"
                 }
             }
         },
-        "internal_flight.PriceRange": {
+        "flight.Flight": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "type": "string"
+                },
+                "airline": {
+                    "$ref": "#/definitions/flight.Airline"
+                },
+                "alternative_flight_id": {
+                    "description": "AlternativeFlightID is set when this flight has fewer seats than passengers,\npointing at the cheapest flight in the result that can seat everyone",
+                    "type": "string"
+                },
+                "amenities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "arrival": {
+                    "$ref": "#/definitions/flight.LocationTime"
+                },
+                "available_seats": {
+                    "type": "integer"
+                },
+                "baggage": {
+                    "$ref": "#/definitions/flight.Baggage"
+                },
+                "best_value_score": {
+                    "type": "number"
+                },
+                "cabin_class": {
+                    "type": "string"
+                },
+                "departure": {
+                    "$ref": "#/definitions/flight.LocationTime"
+                },
+                "duration": {
+                    "$ref": "#/definitions/flight.Duration"
+                },
+                "estimated_total": {
+                    "$ref": "#/definitions/flight.Price"
+                },
+                "flight_number": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_seats_together": {
+                    "description": "MaxSeatsTogether is the largest block of adjacent free seats, nil when the provider doesn't say",
+                    "type": "integer"
+                },
+                "offer_token": {
+                    "description": "OfferToken is the provider's opaque booking reference for this fare, passed through as is.\nEmpty when the provider doesn't return one.",
+                    "type": "string"
+                },
+                "price": {
+                    "$ref": "#/definitions/flight.Price"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "seats_together_available": {
+                    "description": "SeatsTogetherAvailable is set by the seats_together filter when the provider confirmed adjacency",
+                    "type": "boolean"
+                },
+                "stops": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.FlightSearchResponse": {
+            "type": "object",
+            "properties": {
+                "flights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flight.Flight"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/flight.Metadata"
+                },
+                "search_criteria": {
+                    "$ref": "#/definitions/flight.SearchRequest"
+                }
+            }
+        },
+        "flight.LocationTime": {
+            "type": "object",
+            "properties": {
+                "airport": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "datetime": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.Metadata": {
+            "type": "object",
+            "properties": {
+                "cache_hit": {
+                    "type": "boolean"
+                },
+                "cache_key": {
+                    "type": "string"
+                },
+                "data_age_seconds": {
+                    "description": "how long ago the providers were asked, 0 on a fresh fetch",
+                    "type": "integer"
+                },
+                "page": {
+                    "description": "Page describes the returned window of a filter response, nil on plain searches",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/flight.PageMetadata"
+                        }
+                    ]
+                },
+                "provider_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flight.ProviderError"
+                    }
+                },
+                "providers_failed": {
+                    "type": "integer"
+                },
+                "providers_queried": {
+                    "type": "integer"
+                },
+                "providers_skipped": {
+                    "type": "integer"
+                },
+                "providers_succeeded": {
+                    "type": "integer"
+                },
+                "search_time_ms": {
+                    "type": "integer"
+                },
+                "skipped_flights": {
+                    "type": "integer"
+                },
+                "total_results": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.MultiCityRequest": {
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flight.SearchRequest"
+                    }
+                }
+            }
+        },
+        "flight.MultiCityResponse": {
+            "type": "object",
+            "properties": {
+                "legs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/flight.FlightSearchResponse"
+                    }
+                },
+                "total_min_price": {
+                    "$ref": "#/definitions/flight.Price"
+                }
+            }
+        },
+        "flight.PageMetadata": {
+            "type": "object",
+            "properties": {
+                "has_next_page": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_results": {
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.PageRequest": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "description": "1-100, defaults to 20",
+                    "type": "integer"
+                },
+                "offset": {
+                    "description": "number of flights to skip",
+                    "type": "integer"
+                }
+            }
+        },
+        "flight.Price": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.PriceRange": {
             "type": "object",
             "properties": {
                 "high": {
@@ -202,7 +816,36 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_flight.SearchRequest": {
+        "flight.ProviderError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/flight.ErrorCode"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "flight.ProviderHealth": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "healthy, unhealthy",
+                    "type": "string"
+                }
+            }
+        },
+        "flight.SearchRequest": {
             "type": "object",
             "properties": {
                 "cabin_class": {
@@ -225,11 +868,11 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_flight.SortOptions": {
+        "flight.SortOptions": {
             "type": "object",
             "properties": {
                 "by": {
-                    "description": "price, duration, departure_time, arrival_time, best_value",
+                    "description": "price, duration, departure_time, arrival_time, best_value, weighted_best_value",
                     "type": "string"
                 },
                 "order": {
@@ -237,6 +880,43 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "slo.Report": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "p50_ms": {
+                    "type": "number"
+                },
+                "p95_ms": {
+                    "type": "number"
+                },
+                "p99_ms": {
+                    "type": "number"
+                },
+                "within_slo_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "slo.SLOResponse": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/slo.Report"
+                    }
+                },
+                "target_ms": {
+                    "type": "number"
+                },
+                "window_seconds": {
+                    "type": "number"
+                }
+            }
         }
     }
 }`
@@ -244,7 +924,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{"http"},
 	Title:            "Travel Flight API",
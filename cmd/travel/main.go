@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 	"travel/cfg"
+	"travel/internal/admin"
 	"travel/internal/flight"
+	"travel/internal/middleware"
+	"travel/internal/slo"
 	"travel/pkg/cache"
 	"travel/pkg/flightclient"
 	"travel/pkg/logger"
@@ -16,6 +20,9 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // @title           Travel Flight API
@@ -29,6 +36,7 @@ func main() {
 	// ============
 	config, errCfg := cfg.Load()
 	if errCfg != nil {
+		// No structured logger yet, its environment comes from the config
 		log.Fatal(errCfg)
 	}
 
@@ -40,43 +48,154 @@ func main() {
 	// ============
 	// Cache
 	// ============
-	redisAddr := config.RedisConfig.Host + ":" + config.RedisConfig.Port
-	redis := cache.NewRedisCache(redisAddr)
+	redisCfg := config.RedisConfig
+	redis, errRedis := cache.NewRedisCacheFromConfig(cache.RedisConfig{
+		Addr:          redisCfg.Host + ":" + redisCfg.Port,
+		Username:      redisCfg.Username,
+		Password:      redisCfg.Password,
+		DB:            redisCfg.DB,
+		TLSEnabled:    redisCfg.TLSEnabled,
+		TLSSkipVerify: redisCfg.TLSSkipVerify,
+		PoolSize:      redisCfg.PoolSize,
+		DialTimeout:   time.Duration(redisCfg.DialTimeoutMs) * time.Millisecond,
+		ReadTimeout:   time.Duration(redisCfg.ReadTimeoutMs) * time.Millisecond,
+		WriteTimeout:  time.Duration(redisCfg.WriteTimeoutMs) * time.Millisecond,
+	}, cache.WithCompression(redisCfg.CompressThresholdBytes))
+	if errRedis != nil {
+		zlogger.Fatal("redis_config_err", logger.ErrField(errRedis))
+	}
+	// Construction never dials, so a wrong password, DB or TLS setting would only show up as failing searches
+	pingCtx, cancelPing := context.WithTimeout(context.Background(), 3*time.Second)
+	errPing := redis.Ping(pingCtx)
+	cancelPing()
+	if errPing != nil {
+		zlogger.Fatal("redis_connect_err", logger.ErrField(errPing))
+	}
+	flightCache := cache.NewTieredCache(redis, config.LocalCacheConfig.Size,
+		time.Duration(config.LocalCacheConfig.TTLSeconds)*time.Second, zlogger)
 
 	// ============
 	// External Service
 	// ============
+	// Providers get the W3C traceparent of the request that triggered the search
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 	httpClient := &http.Client{
-		Timeout: 5 * time.Second,
+		Timeout:   5 * time.Second,
+		Transport: flightclient.NewOTelTransport(http.DefaultTransport),
 	}
-	airAsiaClient := flightclient.NewAirAsiaClient(httpClient, config.AirAsiaClientConfig.BaseURL, zlogger)
-	batikAirClient := flightclient.NewBatikAirClient(httpClient, config.AirAsiaClientConfig.BaseURL, zlogger)
-	garudaClient := flightclient.NewGarudaClient(httpClient, config.GarudaClientConfig.BaseURL, zlogger)
-	lionAirClient := flightclient.NewLionAirClient(httpClient, config.LionAirClientConfig.BaseURL, zlogger)
-	flightClient := flightclient.NewFlightClient(airAsiaClient, batikAirClient, garudaClient, lionAirClient, zlogger)
+	emptyBodyOpt := flightclient.WithEmptyBodyAsError(config.ProviderEmptyBodyAsError)
+	rateLimit := func(qps, burst int) flightclient.ClientOption {
+		return flightclient.WithRateLimit(float64(qps), burst, config.ProviderRateLimitFailFast)
+	}
+	airAsiaClient := flightclient.NewAirAsiaClient(httpClient, config.AirAsiaClientConfig.BaseURL, zlogger, emptyBodyOpt,
+		rateLimit(config.AirAsiaClientConfig.RateLimitQPS, config.AirAsiaClientConfig.RateLimitBurst))
+	batikAirClient := flightclient.NewBatikAirClient(httpClient, config.BatikAirClientConfig.BaseURL, zlogger, emptyBodyOpt,
+		rateLimit(config.BatikAirClientConfig.RateLimitQPS, config.BatikAirClientConfig.RateLimitBurst))
+	garudaClient := flightclient.NewGarudaClient(httpClient, config.GarudaClientConfig.BaseURL, zlogger, emptyBodyOpt,
+		rateLimit(config.GarudaClientConfig.RateLimitQPS, config.GarudaClientConfig.RateLimitBurst))
+	lionAirClient := flightclient.NewLionAirClient(httpClient, config.LionAirClientConfig.BaseURL, zlogger, emptyBodyOpt,
+		rateLimit(config.LionAirClientConfig.RateLimitQPS, config.LionAirClientConfig.RateLimitBurst))
+	flightClient := flightclient.NewFlightClient(airAsiaClient, batikAirClient, garudaClient, lionAirClient, zlogger,
+		flightclient.WithRouteCoverage(flightclient.ProviderAirAsia, flightclient.RouteCoverage{Airports: config.AirAsiaClientConfig.RouteAirports}),
+		flightclient.WithRouteCoverage(flightclient.ProviderBatikAir, flightclient.RouteCoverage{Airports: config.BatikAirClientConfig.RouteAirports}),
+		flightclient.WithRouteCoverage(flightclient.ProviderGaruda, flightclient.RouteCoverage{Airports: config.GarudaClientConfig.RouteAirports}),
+		flightclient.WithRouteCoverage(flightclient.ProviderLionAir, flightclient.RouteCoverage{Airports: config.LionAirClientConfig.RouteAirports}))
 
 	// ============
 	// Inernal Service
 	// ============
-	flightSvc := flight.NewService(flightClient, redis, config.CacheTTLSeconds, zlogger)
+	ancillaryFees := make(map[string]flight.AncillaryFee, len(config.AncillaryFees))
+	for cabin, fee := range config.AncillaryFees {
+		ancillaryFees[cabin] = flight.AncillaryFee{Seat: fee.Seat, Baggage: fee.Baggage}
+	}
+	currencies, errCurrencies := flight.NewCurrencySet(config.SupportedCurrencies)
+	if errCurrencies != nil {
+		zlogger.Fatal("supported_currencies_err", logger.ErrField(errCurrencies))
+	}
+	routeCacheTTL := make(map[string]time.Duration, len(config.RouteCacheTTLSeconds))
+	for route, seconds := range config.RouteCacheTTLSeconds {
+		routeCacheTTL[route] = time.Duration(seconds) * time.Second
+	}
+	flightSvc := flight.NewService(flightClient, flightCache, config.CacheTTLSeconds, zlogger,
+		flight.WithAncillaryFees(ancillaryFees),
+		flight.WithSupportedCurrencies(currencies),
+		flight.WithRouteCacheTTL(routeCacheTTL),
+		flight.WithProviderCacheTTL(config.ProviderCacheTTL),
+		flight.WithProviderWeights(config.ProviderWeights),
+		flight.WithMaxMultiCityLegs(config.MultiCityMaxLegs))
 	flightHandler := flight.NewFlightHandler(flightSvc)
+	adminHandler := admin.NewAdminHandler(zlogger)
+	sloTracker := slo.NewTracker(
+		time.Duration(config.SLOConfig.TargetMs)*time.Millisecond,
+		time.Duration(config.SLOConfig.WindowSeconds)*time.Second,
+		config.SLOConfig.MaxSamples)
+	sloHandler := slo.NewSLOHandler(sloTracker)
 
 	// ============
 	// HTTP
 	// ============
 	r := gin.Default()
+	// Server-to-server deployments leave CORS_ALLOWED_ORIGINS empty and skip CORS entirely
+	if len(config.CORSConfig.AllowedOrigins) > 0 {
+		r.Use(middleware.CORS(middleware.CORSConfig{
+			AllowedOrigins:   config.CORSConfig.AllowedOrigins,
+			AllowedMethods:   config.CORSConfig.AllowedMethods,
+			AllowedHeaders:   config.CORSConfig.AllowedHeaders,
+			MaxAge:           config.CORSConfig.MaxAgeSeconds,
+			AllowCredentials: config.CORSConfig.AllowCredentials,
+		}))
+	}
+	r.Use(middleware.TraceContext())
+	r.Use(middleware.TagSynthetic())
+	r.Use(middleware.TrackLatency(sloTracker))
+	r.Use(middleware.MaxBodySize(config.MaxBodyBytes))
 
-	flightHandler.RegisterRoutes(r)
-	initSwagger(r)
+	routeTimeout := func(ms int) gin.HandlerFunc {
+		return middleware.Timeout(time.Duration(ms) * time.Millisecond)
+	}
+	defaultTimeout := routeTimeout(config.RouteTimeoutConfig.DefaultMs)
+
+	flightHandler.RegisterRoutes(r.Group("",
+		routeTimeout(config.RouteTimeoutConfig.FlightsMs),
+		middleware.Compress(config.ResponseCompressMinBytes)))
+	adminRoutes := r.Group("", defaultTimeout, middleware.RequireAdminSecret(config.AdminSecret))
+	adminHandler.RegisterRoutes(adminRoutes)
+	flightHandler.RegisterAdminRoutes(adminRoutes)
+	sloHandler.RegisterRoutes(r.Group("", defaultTimeout))
+	initSwagger(r.Group("", defaultTimeout))
+	initReadiness(r.Group("", routeTimeout(config.RouteTimeoutConfig.ReadinessMs)), redis, flightCache)
 
 	addr := fmt.Sprintf(":%s", config.AppPort)
 	if err := r.Run(addr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		zlogger.Fatal("server_start_err", logger.ErrField(err))
 	}
 }
 
-func initSwagger(r *gin.Engine) {
+// initReadiness exposes /readyz. A Redis outage only degrades the instance, the local cache
+// layer keeps serving, so the pod stays in the load balancer and reports the outage instead.
+// It also reports how many Redis calls the local cache layer has absorbed since startup.
+func initReadiness(r gin.IRouter, redis *cache.RedisCache, flightCache *cache.TieredCache) {
+	r.GET("/readyz", func(c *gin.Context) {
+		// The route timeout bounds the request, leave half of it to answer before it fires a 504
+		ctx := c.Request.Context()
+		if deadline, ok := ctx.Deadline(); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+			defer cancel()
+		}
+
+		remoteFailures := flightCache.RemoteFailures()
+		if err := redis.Ping(ctx); err != nil {
+			c.JSON(http.StatusOK, gin.H{"status": "ready", "redis": "degraded", "error": err.Error(), "remote_cache_failures": remoteFailures})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "redis": "ok", "remote_cache_failures": remoteFailures})
+	})
+}
+
+func initSwagger(r gin.IRouter) {
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET("/v1/openapi.json", openAPIHandler(func() (string, error) { return swag.ReadDoc() }))
 	r.GET("/docs", func(c *gin.Context) {
 		c.Header("Content-Type", "text/html; charset=utf-8")
 		html := `<!DOCTYPE html>
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// openAPIHandler serves the spec generated by swag, so clients can find it without the /swagger/ prefix
+func openAPIHandler(readDoc func() (string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		doc, err := readDoc()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "OpenAPI spec unavailable",
+				"code":  "INTERNAL_FAILURE",
+			})
+			return
+		}
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+)
+
+func serveOpenAPI(readDoc func() (string, error)) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/v1/openapi.json", openAPIHandler(readDoc))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/openapi.json", nil))
+	return w
+}
+
+func TestOpenAPIHandler_ServesEmbeddedSpec(t *testing.T) {
+	w := serveOpenAPI(func() (string, error) { return swag.ReadDoc() })
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+
+	var spec struct {
+		Swagger string `json:"swagger"`
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("spec is not valid JSON: %v", err)
+	}
+	// swag v1 emits Swagger 2.0, the OpenAPI 3 "openapi" field is accepted too for when we upgrade
+	if spec.Swagger != "2.0" && !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("expected a swagger 2.0 or openapi 3.x document, got swagger=%q openapi=%q", spec.Swagger, spec.OpenAPI)
+	}
+	if spec.Info.Title == "" || spec.Info.Version == "" {
+		t.Errorf("expected info.title and info.version, got %+v", spec.Info)
+	}
+	if _, ok := spec.Paths["/v1/flights/filter"]["post"]; !ok {
+		t.Errorf("expected POST /v1/flights/filter in paths, got %v", spec.Paths)
+	}
+}
+
+func TestOpenAPIHandler_ReadError(t *testing.T) {
+	w := serveOpenAPI(func() (string, error) { return "", errors.New("no swag doc registered") })
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
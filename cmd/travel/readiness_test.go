@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"travel/internal/middleware"
+	"travel/pkg/cache"
+	"travel/pkg/logger"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadiness_RedisDownIsDegradedNotUnready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mr := miniredis.RunT(t)
+	redis, err := cache.NewRedisCacheFromConfig(cache.RedisConfig{Addr: mr.Addr()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	flightCache := cache.NewTieredCache(redis, 10, time.Minute, logger.NewWithWriter("development", io.Discard))
+
+	r := gin.New()
+	initReadiness(r.Group("", middleware.Timeout(100*time.Millisecond)), redis, flightCache)
+	ready := func() (int, map[string]any) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var body map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode: %v (body %s)", err, w.Body.String())
+		}
+		return w.Code, body
+	}
+
+	if code, body := ready(); code != http.StatusOK || body["redis"] != "ok" {
+		t.Errorf("expected 200 with redis ok, got %d %v", code, body)
+	}
+
+	// The local layer keeps serving, so the pod must stay in the load balancer
+	mr.Close()
+	code, body := ready()
+	if code != http.StatusOK || body["redis"] != "degraded" {
+		t.Errorf("expected 200 with redis degraded, got %d %v", code, body)
+	}
+	if body["error"] == nil || body["remote_cache_failures"] == nil {
+		t.Errorf("expected the redis error and remote failure count, got %v", body)
+	}
+}
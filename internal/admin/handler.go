@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"travel/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	levels logger.LevelController
+}
+
+func NewAdminHandler(levels logger.LevelController) *AdminHandler {
+	return &AdminHandler{
+		levels: levels,
+	}
+}
+
+// RegisterRoutes mounts the admin endpoints, router is expected to carry the admin auth middleware
+func (h *AdminHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/admin/loglevel", h.GetLogLevelHandler)
+	router.PUT("/admin/loglevel", h.SetLogLevelHandler)
+}
+
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// GetLogLevelHandler godoc
+// @Summary      Get the current log level
+// @Tags         admin
+// @Produce      json
+// @Param        X-Admin-Secret header string true "Admin secret"
+// @Success      200 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Router       /admin/loglevel [get]
+func (h *AdminHandler) GetLogLevelHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"level": h.levels.Level()})
+}
+
+// SetLogLevelHandler godoc
+// @Summary      Change the log level at runtime
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Secret header string true "Admin secret"
+// @Param        request body LogLevelRequest true "New level (debug, info, warn, error)"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Router       /admin/loglevel [put]
+func (h *AdminHandler) SetLogLevelHandler(c *gin.Context) {
+	var req LogLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid JSON body",
+			"code":  "VALIDATION_ERROR",
+		})
+		return
+	}
+
+	if err := h.levels.SetLevel(req.Level); err != nil {
+		if errors.Is(err, logger.ErrInvalidLevel) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+				"code":  "VALIDATION_ERROR",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+			"code":  "INTERNAL_FAILURE",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"level": h.levels.Level()})
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"travel/internal/middleware"
+	"travel/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testSecret = "s3cret"
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	log := logger.NewWithWriter("development", io.Discard)
+	t.Cleanup(func() { _ = log.SetLevel("debug") })
+
+	r := gin.New()
+	NewAdminHandler(log).RegisterRoutes(r.Group("", middleware.RequireAdminSecret(testSecret)))
+	return r
+}
+
+func doRequest(r http.Handler, method, body, secret string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/admin/loglevel", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(middleware.AdminSecretHeader, secret)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestLogLevel_ChangesLevel(t *testing.T) {
+	r := newTestRouter(t)
+
+	w := doRequest(r, http.MethodPut, `{"level":"warn"}`, testSecret)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodGet, "", testSecret)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"level":"warn"`) {
+		t.Errorf("expected level warn, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestLogLevel_Rejects(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		secret     string
+		wantStatus int
+	}{
+		{name: "invalid level", method: http.MethodPut, body: `{"level":"verbose"}`, secret: testSecret, wantStatus: http.StatusBadRequest},
+		{name: "missing level", method: http.MethodPut, body: `{}`, secret: testSecret, wantStatus: http.StatusBadRequest},
+		{name: "missing secret", method: http.MethodGet, wantStatus: http.StatusUnauthorized},
+		{name: "wrong secret", method: http.MethodPut, body: `{"level":"error"}`, secret: "guess", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(t)
+			w := doRequest(r, tt.method, tt.body, tt.secret)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if got := doRequest(r, http.MethodGet, "", testSecret).Body.String(); !strings.Contains(got, `"level":"debug"`) {
+				t.Errorf("expected level unchanged, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package flight
+
+import (
+	"strings"
+	"time"
+)
+
+// RouteKey identifies a route for TTL overrides, e.g. "CGK-SIN". Routes are directional.
+func RouteKey(origin, destination string) string {
+	return strings.ToUpper(origin) + "-" + strings.ToUpper(destination)
+}
+
+// WithRouteCacheTTL overrides the cache TTL for specific routes keyed by RouteKey.
+// Routes without an entry, and entries that are not positive, use the default TTL.
+func WithRouteCacheTTL(overrides map[string]time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.routeTTL = make(map[string]time.Duration, len(overrides))
+		for route, ttl := range overrides {
+			if ttl > 0 {
+				s.routeTTL[strings.ToUpper(route)] = ttl
+			}
+		}
+	}
+}
+
+// cacheTTL is how long a search response for req stays cached
+func (s *Service) cacheTTL(req SearchRequest) time.Duration {
+	if ttl, ok := s.routeTTL[RouteKey(req.Origin, req.Destination)]; ok {
+		return ttl
+	}
+	return s.ttl
+}
+
+// WithProviderCacheTTL overrides the cache TTL per provider, keyed by Flight.Provider.
+// Entries that are not positive are ignored.
+func WithProviderCacheTTL(overrides map[string]time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.providerTTL = make(map[string]time.Duration, len(overrides))
+		for provider, ttl := range overrides {
+			if ttl > 0 {
+				s.providerTTL[provider] = ttl
+			}
+		}
+	}
+}
+
+// responseCacheTTL is how long the provider response for req stays cached. It is the shortest TTL among
+// the providers that returned flights, so the most volatile fares decide. Providers without an override,
+// and responses without flights, use the route TTL.
+func (s *Service) responseCacheTTL(req SearchRequest, flights []Flight) time.Duration {
+	base := s.cacheTTL(req)
+	if len(s.providerTTL) == 0 || len(flights) == 0 {
+		return base
+	}
+
+	var ttl time.Duration
+	for _, f := range flights {
+		providerTTL, ok := s.providerTTL[f.Provider]
+		if !ok {
+			providerTTL = base
+		}
+		if ttl == 0 || providerTTL < ttl {
+			ttl = providerTTL
+		}
+	}
+	return ttl
+}
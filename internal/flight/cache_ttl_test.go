@@ -0,0 +1,129 @@
+package flight
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+	"travel/pkg/cache"
+	"travel/pkg/logger"
+)
+
+func TestService_CacheTTL(t *testing.T) {
+	s := NewService(newMockClient(t), cache.NewMemoryCache(), 30, logger.NewWithWriter("development", io.Discard),
+		WithRouteCacheTTL(map[string]time.Duration{
+			"cgk-sin": time.Hour,
+			"CGK-DPS": 0, // ignored, not positive
+		}))
+
+	tests := []struct {
+		name        string
+		origin      string
+		destination string
+		want        time.Duration
+	}{
+		{name: "overridden route", origin: "CGK", destination: "SIN", want: time.Hour},
+		{name: "override is case-insensitive", origin: "cgk", destination: "sin", want: time.Hour},
+		{name: "reverse direction uses default", origin: "SIN", destination: "CGK", want: 30 * time.Second},
+		{name: "zero override uses default", origin: "CGK", destination: "DPS", want: 30 * time.Second},
+		{name: "unknown route uses default", origin: "SUB", destination: "DPS", want: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.cacheTTL(SearchRequest{Origin: tt.origin, Destination: tt.destination})
+			if got != tt.want {
+				t.Errorf("expected TTL %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_CacheWriteUsesRouteTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]time.Duration
+		want      time.Duration
+	}{
+		{name: "default route", want: 60 * time.Second},
+		{name: "overridden route", overrides: map[string]time.Duration{"CGK-DPS": 2 * time.Hour}, want: 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cache.NewMemoryCache()
+			client := newMockClient(t)
+			expectSearch(client, testSearchRequest, FlightSearchResponse{Flights: testFlights()})
+			s := NewService(client, c, 60, logger.NewWithWriter("development", io.Discard), WithRouteCacheTTL(tt.overrides))
+
+			if _, err := s.SearchFlights(context.Background(), testSearchRequest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the cache write happens in the background
+			key := s.generateCacheKey(testSearchRequest)
+			var ttl time.Duration
+			for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+				var err error
+				if ttl, err = c.TTL(context.Background(), key); err == nil {
+					break
+				}
+			}
+			if ttl <= tt.want-time.Second || ttl > tt.want {
+				t.Errorf("expected TTL close to %v, got %v", tt.want, ttl)
+			}
+		})
+	}
+}
+
+func TestService_ResponseCacheTTL(t *testing.T) {
+	s := NewService(newMockClient(t), cache.NewMemoryCache(), 30, logger.NewWithWriter("development", io.Discard),
+		WithRouteCacheTTL(map[string]time.Duration{"CGK-SIN": time.Hour}),
+		WithProviderCacheTTL(map[string]time.Duration{
+			"Lion Air":  5 * time.Minute,
+			"AirAsia":   time.Hour,
+			"Batik Air": 0, // ignored, not positive
+		}))
+
+	flights := func(providers ...string) []Flight {
+		out := make([]Flight, len(providers))
+		for i, p := range providers {
+			out[i] = Flight{ID: p, Provider: p}
+		}
+		return out
+	}
+	cgkDps := SearchRequest{Origin: "CGK", Destination: "DPS"}
+	cgkSin := SearchRequest{Origin: "CGK", Destination: "SIN"}
+
+	tests := []struct {
+		name    string
+		req     SearchRequest
+		flights []Flight
+		want    time.Duration
+	}{
+		{name: "no flights uses global", req: cgkDps, want: 30 * time.Second},
+		{name: "single provider override", req: cgkDps, flights: flights("AirAsia"), want: time.Hour},
+		{name: "shortest provider wins", req: cgkDps, flights: flights("AirAsia", "Lion Air", "AirAsia"), want: 5 * time.Minute},
+		{name: "provider without override counts as global", req: cgkDps, flights: flights("AirAsia", "Garuda Indonesia"), want: 30 * time.Second},
+		{name: "zero override counts as global", req: cgkDps, flights: flights("AirAsia", "Batik Air"), want: 30 * time.Second},
+		{name: "provider without override counts as route", req: cgkSin, flights: flights("AirAsia", "Garuda Indonesia"), want: time.Hour},
+		{name: "provider shorter than route", req: cgkSin, flights: flights("Lion Air", "Garuda Indonesia"), want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.responseCacheTTL(tt.req, tt.flights); got != tt.want {
+				t.Errorf("expected TTL %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestService_ResponseCacheTTLWithoutOverrides(t *testing.T) {
+	s := NewService(newMockClient(t), cache.NewMemoryCache(), 30, logger.NewWithWriter("development", io.Discard))
+
+	got := s.responseCacheTTL(SearchRequest{Origin: "CGK", Destination: "DPS"}, []Flight{{Provider: "Lion Air"}})
+	if got != 30*time.Second {
+		t.Errorf("expected the global TTL, got %v", got)
+	}
+}
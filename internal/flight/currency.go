@@ -0,0 +1,98 @@
+package flight
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Currency describes an ISO-4217 currency the API can quote prices in
+type Currency struct {
+	Code          string `json:"code"`
+	Symbol        string `json:"symbol"`
+	DecimalPlaces int    `json:"decimal_places"`
+}
+
+// isoCurrencies is the subset of ISO-4217 we know how to display.
+// A currency has to be listed here before it can be configured as supported.
+var isoCurrencies = map[string]Currency{
+	"AUD": {Code: "AUD", Symbol: "A$", DecimalPlaces: 2},
+	"CNY": {Code: "CNY", Symbol: "¥", DecimalPlaces: 2},
+	"EUR": {Code: "EUR", Symbol: "€", DecimalPlaces: 2},
+	"GBP": {Code: "GBP", Symbol: "£", DecimalPlaces: 2},
+	"HKD": {Code: "HKD", Symbol: "HK$", DecimalPlaces: 2},
+	"IDR": {Code: "IDR", Symbol: "Rp", DecimalPlaces: 0},
+	"INR": {Code: "INR", Symbol: "₹", DecimalPlaces: 2},
+	"JPY": {Code: "JPY", Symbol: "¥", DecimalPlaces: 0},
+	"KRW": {Code: "KRW", Symbol: "₩", DecimalPlaces: 0},
+	"MYR": {Code: "MYR", Symbol: "RM", DecimalPlaces: 2},
+	"PHP": {Code: "PHP", Symbol: "₱", DecimalPlaces: 2},
+	"SGD": {Code: "SGD", Symbol: "S$", DecimalPlaces: 2},
+	"THB": {Code: "THB", Symbol: "฿", DecimalPlaces: 2},
+	"USD": {Code: "USD", Symbol: "$", DecimalPlaces: 2},
+	"VND": {Code: "VND", Symbol: "₫", DecimalPlaces: 0},
+}
+
+// DefaultCurrency is what every provider quotes in today
+const DefaultCurrency = "IDR"
+
+// ValidateCurrencyCode checks that code is one of the ISO-4217 codes listed in isoCurrencies.
+// Real codes missing from that list (CHF, CAD, ...) are rejected as unsupported, not as invalid ISO codes.
+func ValidateCurrencyCode(code string) error {
+	// Every key in isoCurrencies is three upper case letters, so this also rejects "idr" or "RUPIAH"
+	if _, ok := isoCurrencies[code]; !ok {
+		return NewValidationError(ErrorCodeInvalidCurrency, fmt.Sprintf("unsupported currency %q", code))
+	}
+	return nil
+}
+
+// CurrencySet is the list of currencies this deployment supports
+type CurrencySet struct {
+	currencies map[string]Currency
+}
+
+// NewCurrencySet builds the supported set from ISO-4217 codes, rejecting unknown codes
+func NewCurrencySet(codes []string) (*CurrencySet, error) {
+	set := &CurrencySet{currencies: make(map[string]Currency, len(codes))}
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if err := ValidateCurrencyCode(code); err != nil {
+			return nil, err
+		}
+		set.currencies[code] = isoCurrencies[code]
+	}
+	return set, nil
+}
+
+// Validate checks that code is a known currency and supported by this deployment
+func (cs *CurrencySet) Validate(code string) error {
+	if err := ValidateCurrencyCode(code); err != nil {
+		return err
+	}
+	if _, ok := cs.currencies[code]; !ok {
+		return NewValidationError(ErrorCodeUnsupportedCurrency, fmt.Sprintf("currency %s is not supported", code))
+	}
+	return nil
+}
+
+// List returns the supported currencies ordered by code
+func (cs *CurrencySet) List() []Currency {
+	list := make([]Currency, 0, len(cs.currencies))
+	for _, c := range cs.currencies {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// WithSupportedCurrencies replaces the default set, which only holds IDR
+func WithSupportedCurrencies(set *CurrencySet) ServiceOption {
+	return func(s *Service) {
+		s.currencies = set
+	}
+}
+
+// SupportedCurrencies lists the currencies prices can be quoted in
+func (s *Service) SupportedCurrencies() []Currency {
+	return s.currencies.List()
+}
@@ -0,0 +1,104 @@
+package flight
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestValidateCurrencyCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		wantCode ErrorCode
+	}{
+		{code: "IDR"},
+		{code: "USD"},
+		{code: "JPY"},
+		{code: "", wantCode: ErrorCodeInvalidCurrency},
+		{code: "idr", wantCode: ErrorCodeInvalidCurrency},
+		{code: "RUPIAH", wantCode: ErrorCodeInvalidCurrency},
+		{code: "XXQ", wantCode: ErrorCodeInvalidCurrency},
+		{code: "CHF", wantCode: ErrorCodeInvalidCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			err := ValidateCurrencyCode(tt.code)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("expected %q to be valid, got %v", tt.code, err)
+				}
+				return
+			}
+			var vErr *ValidationError
+			if !errors.As(err, &vErr) || vErr.Code() != tt.wantCode {
+				t.Errorf("expected %s for %q, got %v", tt.wantCode, tt.code, err)
+			}
+		})
+	}
+}
+
+func TestCurrencySet(t *testing.T) {
+	set, err := NewCurrencySet([]string{"usd", " IDR ", "SGD"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := set.Validate("SGD"); err != nil {
+		t.Errorf("expected SGD to be supported, got %v", err)
+	}
+	var vErr *ValidationError
+	if err := set.Validate("EUR"); !errors.As(err, &vErr) || vErr.Code() != ErrorCodeUnsupportedCurrency {
+		t.Errorf("expected EUR to be unsupported, got %v", err)
+	}
+	if err := set.Validate("EURO"); !errors.As(err, &vErr) || vErr.Code() != ErrorCodeInvalidCurrency {
+		t.Errorf("expected EURO to be invalid, got %v", err)
+	}
+
+	list := set.List()
+	if len(list) != 3 || list[0].Code != "IDR" || list[1].Code != "SGD" || list[2].Code != "USD" {
+		t.Errorf("expected currencies sorted by code, got %+v", list)
+	}
+
+	if _, err := NewCurrencySet([]string{"IDR", "ABC"}); err == nil {
+		t.Errorf("expected unknown code to be rejected")
+	}
+}
+
+func TestListCurrenciesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	set, _ := NewCurrencySet([]string{"IDR", "USD"})
+	svc := newTestService(newMockClient(t), nil)
+	WithSupportedCurrencies(set)(svc)
+
+	r := gin.New()
+	NewFlightHandler(svc).RegisterRoutes(r)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/currencies", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var body struct {
+		Currencies []Currency `json:"currencies"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []Currency{
+		{Code: "IDR", Symbol: "Rp", DecimalPlaces: 0},
+		{Code: "USD", Symbol: "$", DecimalPlaces: 2},
+	}
+	if len(body.Currencies) != len(want) {
+		t.Fatalf("expected %d currencies, got %+v", len(want), body.Currencies)
+	}
+	for i := range want {
+		if body.Currencies[i] != want[i] {
+			t.Errorf("currency %d: expected %+v, got %+v", i, want[i], body.Currencies[i])
+		}
+	}
+}
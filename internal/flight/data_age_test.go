@@ -0,0 +1,72 @@
+package flight
+
+import (
+	"context"
+	"testing"
+	"time"
+	"travel/pkg/cache"
+)
+
+func TestService_DataAgeSeconds(t *testing.T) {
+	c := cache.NewMemoryCache()
+	// Times(1) by default, the second search has to be served from the cache
+	client := newMockClient(t)
+	expectSearch(client, testSearchRequest, FlightSearchResponse{
+		Flights:  testFlights(),
+		Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+	})
+	s := newTestService(client, c)
+	now := time.Date(2099, 12, 1, 10, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	fresh, err := s.SearchFlights(context.Background(), testSearchRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fresh.Metadata.CacheHit || fresh.Metadata.DataAgeSeconds != 0 {
+		t.Fatalf("expected a fresh fetch with age 0, got %+v", fresh.Metadata)
+	}
+
+	// the cache write happens in the background
+	key := s.generateCacheKey(testSearchRequest)
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if ok, _ := c.Exists(context.Background(), key); ok {
+			break
+		}
+	}
+
+	now = now.Add(42*time.Second + 500*time.Millisecond)
+	hit, err := s.SearchFlights(context.Background(), testSearchRequest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hit.Metadata.CacheHit {
+		t.Fatalf("expected a cache hit, got %+v", hit.Metadata)
+	}
+	if hit.Metadata.DataAgeSeconds != 42 {
+		t.Errorf("expected age 42s, got %d", hit.Metadata.DataAgeSeconds)
+	}
+}
+
+func TestDataAgeSeconds(t *testing.T) {
+	now := time.Date(2099, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		fetchedAt time.Time
+		want      uint32
+	}{
+		{name: "just fetched", fetchedAt: now, want: 0},
+		{name: "two minutes old", fetchedAt: now.Add(-2 * time.Minute), want: 120},
+		{name: "fetched in the future (clock skew)", fetchedAt: now.Add(time.Minute), want: 0},
+		{name: "unknown fetch time", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dataAgeSeconds(tt.fetchedAt, now); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package flight
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type ErrorCode string
+
+const (
+	ErrorCodeTimeout         ErrorCode = "TIMEOUT"
+	ErrorCodeInternalFailure ErrorCode = "INTERNAL_FAILURE"
+	ErrorCodeCacheFailure    ErrorCode = "CACHE_FAILURE"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+
+	ErrorCodeValidation            ErrorCode = "VALIDATION_ERROR"
+	ErrorCodeInvalidDateFormat     ErrorCode = "INVALID_DATE_FORMAT"
+	ErrorCodeDeparturePast         ErrorCode = "DEPARTURE_IN_PAST"
+	ErrorCodeReturnBeforeDeparture ErrorCode = "RETURN_BEFORE_DEPARTURE"
+	ErrorCodeInvalidPassengerCount ErrorCode = "INVALID_PASSENGER_COUNT"
+	ErrorCodeSameOriginDestination ErrorCode = "SAME_ORIGIN_DESTINATION"
+	ErrorCodeInvalidItinerary      ErrorCode = "INVALID_ITINERARY"
+	ErrorCodeLegDatesOutOfOrder    ErrorCode = "LEG_DATES_OUT_OF_ORDER"
+	ErrorCodeTooManyLegs           ErrorCode = "TOO_MANY_LEGS"
+	ErrorCodeInvalidCurrency       ErrorCode = "INVALID_CURRENCY"
+	ErrorCodeUnsupportedCurrency   ErrorCode = "UNSUPPORTED_CURRENCY"
+
+	ErrorCodeProviderFailed ErrorCode = "PROVIDER_FAILURE"
+	ErrorCodeRateLimited    ErrorCode = "RATE_LIMITED"
+)
+
+// DomainError is implemented by every error the flight domain hands back to the transport layer
+type DomainError interface {
+	error
+	Code() ErrorCode
+	HTTPStatus() int
+}
+
+// ValidationError is returned when the request itself is wrong
+type ValidationError struct {
+	code    ErrorCode
+	Message string
+	// Field is the offending request field, e.g. "legs[1].departure_date". Empty when the error is not about one field.
+	Field string
+}
+
+func NewValidationError(code ErrorCode, message string) *ValidationError {
+	return &ValidationError{code: code, Message: message}
+}
+
+// NewFieldValidationError is a ValidationError pinned to a request field
+func NewFieldValidationError(code ErrorCode, field, message string) *ValidationError {
+	return &ValidationError{code: code, Message: message, Field: field}
+}
+
+func (e *ValidationError) Error() string   { return e.Message }
+func (e *ValidationError) Code() ErrorCode { return e.code }
+func (e *ValidationError) HTTPStatus() int { return http.StatusBadRequest }
+
+// ProviderUnavailableError is returned when an airline provider could not be reached or answered badly
+type ProviderUnavailableError struct {
+	Provider string
+	code     ErrorCode
+	Err      error
+}
+
+func NewProviderUnavailableError(provider string, code ErrorCode, err error) *ProviderUnavailableError {
+	return &ProviderUnavailableError{Provider: provider, code: code, Err: err}
+}
+
+func (e *ProviderUnavailableError) Error() string {
+	return fmt.Sprintf("provider %s unavailable: %v", e.Provider, e.Err)
+}
+func (e *ProviderUnavailableError) Unwrap() error   { return e.Err }
+func (e *ProviderUnavailableError) Code() ErrorCode { return e.code }
+
+func (e *ProviderUnavailableError) HTTPStatus() int {
+	if e.code == ErrorCodeTimeout {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusServiceUnavailable
+}
+
+// CacheError wraps a failing cache operation
+type CacheError struct {
+	Op  string
+	Err error
+}
+
+func NewCacheError(op string, err error) *CacheError {
+	return &CacheError{Op: op, Err: err}
+}
+
+func (e *CacheError) Error() string   { return fmt.Sprintf("cache %s failed: %v", e.Op, e.Err) }
+func (e *CacheError) Unwrap() error   { return e.Err }
+func (e *CacheError) Code() ErrorCode { return ErrorCodeCacheFailure }
+func (e *CacheError) HTTPStatus() int { return http.StatusInternalServerError }
+
+// NotFoundError is returned when the requested resource does not exist
+type NotFoundError struct {
+	Resource string
+}
+
+func NewNotFoundError(resource string) *NotFoundError {
+	return &NotFoundError{Resource: resource}
+}
+
+func (e *NotFoundError) Error() string   { return fmt.Sprintf("%s not found", e.Resource) }
+func (e *NotFoundError) Code() ErrorCode { return ErrorCodeNotFound }
+func (e *NotFoundError) HTTPStatus() int { return http.StatusNotFound }
@@ -0,0 +1,137 @@
+package flight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDomainError_HTTPStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        DomainError
+		wantStatus int
+		wantCode   ErrorCode
+	}{
+		{
+			name:       "validation",
+			err:        NewValidationError(ErrorCodeDeparturePast, "departure_date cannot be in the past"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrorCodeDeparturePast,
+		},
+		{
+			name:       "provider failure",
+			err:        NewProviderUnavailableError("AirAsia", ErrorCodeProviderFailed, errors.New("boom")),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   ErrorCodeProviderFailed,
+		},
+		{
+			name:       "provider timeout",
+			err:        NewProviderUnavailableError("AirAsia", ErrorCodeTimeout, context.DeadlineExceeded),
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   ErrorCodeTimeout,
+		},
+		{
+			name:       "cache",
+			err:        NewCacheError("get", errors.New("connection refused")),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrorCodeCacheFailure,
+		},
+		{
+			name:       "not found",
+			err:        NewNotFoundError("flight"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrorCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.HTTPStatus(); got != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, got)
+			}
+			if got := tt.err.Code(); got != tt.wantCode {
+				t.Errorf("expected code %s, got %s", tt.wantCode, got)
+			}
+		})
+	}
+}
+
+func TestSendError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   ErrorCode
+		wantField  string
+	}{
+		{
+			name:       "field validation error",
+			err:        fmt.Errorf("validation error: %w", NewFieldValidationError(ErrorCodeLegDatesOutOfOrder, "legs[1].departure_date", "leg 2 departs before leg 1")),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrorCodeLegDatesOutOfOrder,
+			wantField:  "legs[1].departure_date",
+		},
+		{
+			name:       "wrapped validation error",
+			err:        fmt.Errorf("validation error: %w", NewValidationError(ErrorCodeInvalidPassengerCount, "passengers must be at least 1")),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrorCodeInvalidPassengerCount,
+		},
+		{
+			name:       "not found",
+			err:        NewNotFoundError("flight"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrorCodeNotFound,
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("something broke"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrorCodeInternalFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			sendError(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid json body: %v", err)
+			}
+			if body["code"] != string(tt.wantCode) {
+				t.Errorf("expected code %s, got %v", tt.wantCode, body["code"])
+			}
+			if field, _ := body["field"].(string); field != tt.wantField {
+				t.Errorf("expected field %q, got %v", tt.wantField, body["field"])
+			}
+		})
+	}
+}
+
+func TestSearchRequest_ValidateReturnsValidationError(t *testing.T) {
+	req := SearchRequest{Origin: "CGK", Destination: "CGK", DepartureDate: "2099-01-01", Passengers: 1}
+
+	var validationErr *ValidationError
+	if err := req.Validate(); !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if validationErr.Code() != ErrorCodeSameOriginDestination {
+		t.Errorf("expected %s, got %s", ErrorCodeSameOriginDestination, validationErr.Code())
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+	"travel/internal/middleware"
+	"travel/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,11 +22,31 @@ func NewFlightHandler(s *Service) *FlightHandler {
 	}
 }
 
-func (h *FlightHandler) RegisterRoutes(router *gin.Engine) {
+func (h *FlightHandler) RegisterRoutes(router gin.IRouter) {
 	router.POST("/v1/flights/search", h.SearchFlightsHandler)
-	router.POST("/v1/flights/filter", h.FilterFlightsHandler)
+	// Filter results are stable for as long as the search stays cached, so clients can revalidate
+	router.POST("/v1/flights/filter", middleware.ETagger(), h.FilterFlightsHandler)
+	router.POST("/v1/flights/multicity", h.SearchMultiCityHandler)
+	router.GET("/v1/flights/providers/health", h.ProvidersHealthHandler)
+	router.GET("/v1/currencies", h.ListCurrenciesHandler)
 }
 
+// RegisterAdminRoutes mounts the operator-only flight endpoints, router is expected to carry the admin auth middleware
+func (h *FlightHandler) RegisterAdminRoutes(router gin.IRouter) {
+	router.POST("/v1/flights/cache/invalidate", h.InvalidateCacheHandler)
+}
+
+// SearchFlightsHandler godoc
+// @Summary      Search flights across every airline provider
+// @Description  Query all providers in parallel, results are cached per search
+// @Tags         flights
+// @Accept       json
+// @Produce      json
+// @Param        request body SearchRequest true "Search Criteria"
+// @Param        format query string false "Response format" Enums(native, offer)
+// @Success      200 {object} FlightSearchResponse
+// @Failure      400 {object} map[string]string
+// @Router       /v1/flights/search [post]
 func (h *FlightHandler) SearchFlightsHandler(c *gin.Context) {
 	var req SearchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -41,7 +63,7 @@ func (h *FlightHandler) SearchFlightsHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeFlights(c, response)
 }
 
 // FilterFlightsHandler godoc
@@ -51,6 +73,7 @@ func (h *FlightHandler) SearchFlightsHandler(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body FilterRequest true "Filter Criteria"
+// @Param        format query string false "Response format" Enums(native, offer)
 // @Success      200 {object} map[string]interface{}
 // @Failure      400 {object} map[string]string
 // @Router       /v1/flights/filter [post]
@@ -69,17 +92,110 @@ func (h *FlightHandler) FilterFlightsHandler(c *gin.Context) {
 		return
 	}
 
+	writeFlights(c, response)
+}
+
+// SearchMultiCityHandler godoc
+// @Summary      Search a one-way multi-city itinerary
+// @Description  Search every leg (2 to 5) in parallel, each leg is cached on its own
+// @Tags         flights
+// @Accept       json
+// @Produce      json
+// @Param        request body MultiCityRequest true "Itinerary Legs"
+// @Success      200 {object} MultiCityResponse
+// @Failure      400 {object} map[string]string
+// @Router       /v1/flights/multicity [post]
+func (h *FlightHandler) SearchMultiCityHandler(c *gin.Context) {
+	var req MultiCityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid JSON body",
+			"code":  ErrorCodeValidation,
+		})
+		return
+	}
+
+	response, err := h.service.SearchMultiCity(c.Request.Context(), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
+// InvalidateCacheHandler godoc
+// @Summary      Invalidate cached flight results
+// @Description  Drop the cached search result for a route so the next search hits the providers
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        X-Admin-Secret header string true "Admin secret"
+// @Param        request body SearchRequest true "Search Criteria"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Router       /v1/flights/cache/invalidate [post]
+func (h *FlightHandler) InvalidateCacheHandler(c *gin.Context) {
+	var req SearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid JSON body",
+			"code":  ErrorCodeValidation,
+		})
+		return
+	}
+
+	cacheKey, err := h.service.InvalidateCache(c.Request.Context(), req)
+	if err != nil {
+		sendError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cache_key": cacheKey,
+	})
+}
+
+// ListCurrenciesHandler godoc
+// @Summary      List supported currencies
+// @Description  ISO-4217 currencies prices can be quoted in, with symbol and decimal places
+// @Tags         reference
+// @Produce      json
+// @Success      200 {object} map[string][]Currency
+// @Router       /v1/currencies [get]
+func (h *FlightHandler) ListCurrenciesHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"currencies": h.service.SupportedCurrencies(),
+	})
+}
+
+// ProvidersHealthHandler godoc
+// @Summary      Check airline provider health
+// @Description  Probe every provider in parallel within 2 seconds. Always 200, each entry says whether that provider is healthy
+// @Tags         flights
+// @Produce      json
+// @Success      200 {object} map[string][]ProviderHealth
+// @Router       /v1/flights/providers/health [get]
+func (h *FlightHandler) ProvidersHealthHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"providers": h.service.ProvidersHealth(c.Request.Context()),
+	})
+}
+
 func sendError(c *gin.Context, err error) {
-	var appErr *AppError
+	var domainErr DomainError
 
-	if errors.As(err, &appErr) {
-		c.JSON(appErr.Status, gin.H{
-			"error": appErr.Message,
-			"code":  appErr.Code,
-		})
+	if errors.As(err, &domainErr) {
+		body := gin.H{
+			"error": domainErr.Error(),
+			"code":  domainErr.Code(),
+		}
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) && validationErr.Field != "" {
+			body["field"] = validationErr.Field
+		}
+		c.JSON(domainErr.HTTPStatus(), body)
 		return
 	}
 
@@ -96,7 +212,11 @@ func (s *Service) FilterFlights(ctx context.Context, req FilterRequest) (*Flight
 	if err := req.SearchRequest.Validate(); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
-	flights, metadata, err := s.getOrFetchFlights(ctx, req.SearchRequest)
+	if err := req.Page.Validate(); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+	log := s.requestLogger(ctx, req.SearchRequest)
+	flights, metadata, err := s.getOrFetchFlights(ctx, log, req.SearchRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +226,11 @@ func (s *Service) FilterFlights(ctx context.Context, req FilterRequest) (*Flight
 	if req.Sort != nil {
 		flights = s.applySorting(flights, *req.Sort)
 	}
-	metadata.TotalResults = uint32(len(flights))
+	flights = s.applyEstimatedTotals(flights, req.Passengers)
+	flights = applyAlternatives(flights, req.Passengers)
+	flights, page := applyPagination(flights, req.Page)
+	metadata.TotalResults = page.TotalResults
+	metadata.Page = &page
 	metadata.SearchTimeMs = uint32(time.Since(startTime).Milliseconds())
 
 	return &FlightSearchResponse{
@@ -121,10 +245,13 @@ func (s *Service) SearchFlights(ctx context.Context, req SearchRequest) (*Flight
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	flights, metadata, err := s.getOrFetchFlights(ctx, req)
+	log := s.requestLogger(ctx, req)
+	flights, metadata, err := s.getOrFetchFlights(ctx, log, req)
 	if err != nil {
 		return nil, err
 	}
+	flights = s.applyEstimatedTotals(flights, req.Passengers)
+	flights = applyAlternatives(flights, req.Passengers)
 
 	return &FlightSearchResponse{
 		SearchCriteria: req,
@@ -132,3 +259,23 @@ func (s *Service) SearchFlights(ctx context.Context, req SearchRequest) (*Flight
 		Flights:        flights,
 	}, nil
 }
+
+// ProvidersHealth probes every airline provider, unhealthy ones are reported rather than returned as an error
+func (s *Service) ProvidersHealth(ctx context.Context) []ProviderHealth {
+	return s.flightClient.ProvidersHealth(ctx)
+}
+
+// InvalidateCache removes the cached response for the search and returns the key that was dropped.
+func (s *Service) InvalidateCache(ctx context.Context, req SearchRequest) (string, error) {
+	if err := req.Validate(); err != nil {
+		return "", fmt.Errorf("validation error: %w", err)
+	}
+
+	cacheKey := s.generateCacheKey(req)
+	if err := s.cache.Delete(ctx, cacheKey); err != nil {
+		s.requestLogger(ctx, req).Error("cache_delete_err", logger.Field{Key: "err", Value: err})
+		return "", NewCacheError("delete", err)
+	}
+
+	return cacheKey, nil
+}
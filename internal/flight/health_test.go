@@ -0,0 +1,44 @@
+package flight
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProvidersHealthHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := newMockClient(t)
+	client.EXPECT().ProvidersHealth(gomock.Any()).Return([]ProviderHealth{
+		{Name: "AirAsia", Status: ProviderStatusHealthy, LatencyMs: 145},
+		{Name: "Lion Air", Status: ProviderStatusUnhealthy, LatencyMs: 2000, Error: "context deadline exceeded"},
+	})
+
+	r := gin.New()
+	NewFlightHandler(newTestService(client, nil)).RegisterRoutes(r)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/flights/providers/health", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 even with an unhealthy provider, got %d", w.Code)
+	}
+	var body struct {
+		Providers []ProviderHealth `json:"providers"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Providers) != 2 {
+		t.Fatalf("expected 2 providers, got %+v", body.Providers)
+	}
+	if body.Providers[0] != (ProviderHealth{Name: "AirAsia", Status: ProviderStatusHealthy, LatencyMs: 145}) {
+		t.Errorf("unexpected first provider %+v", body.Providers[0])
+	}
+	if body.Providers[1].Status != ProviderStatusUnhealthy || body.Providers[1].Error == "" {
+		t.Errorf("expected Lion Air to be unhealthy with an error, got %+v", body.Providers[1])
+	}
+}
@@ -0,0 +1,49 @@
+package flight
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"travel/internal/middleware"
+	"travel/pkg/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestInvalidateCacheHandler_RequiresAdminSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewFlightHandler(newTestService(newMockClient(t), cache.NewMemoryCache()))
+
+	r := gin.New()
+	h.RegisterRoutes(r)
+	h.RegisterAdminRoutes(r.Group("", middleware.RequireAdminSecret("s3cret")))
+
+	body, _ := json.Marshal(testSearchRequest)
+	tests := []struct {
+		name   string
+		secret string
+		want   int
+	}{
+		{name: "no secret", want: http.StatusUnauthorized},
+		{name: "wrong secret", secret: "guess", want: http.StatusUnauthorized},
+		{name: "admin secret", secret: "s3cret", want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/flights/cache/invalidate", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.secret != "" {
+				req.Header.Set(middleware.AdminSecretHeader, tt.secret)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
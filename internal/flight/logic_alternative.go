@@ -0,0 +1,28 @@
+package flight
+
+// applyAlternatives points every flight that can't seat all passengers at the cheapest flight that can.
+// Flights are copied, the input slice is left untouched. Nothing is set when no flight has enough seats.
+func applyAlternatives(flights []Flight, passengers uint32) []Flight {
+	out := make([]Flight, len(flights))
+	copy(out, flights)
+
+	cheapest := -1
+	for i, f := range out {
+		if f.AvailableSeats < passengers {
+			continue
+		}
+		if cheapest == -1 || f.Price.Amount < out[cheapest].Price.Amount {
+			cheapest = i
+		}
+	}
+	if cheapest == -1 {
+		return out
+	}
+
+	for i := range out {
+		if out[i].AvailableSeats < passengers {
+			out[i].AlternativeFlightID = out[cheapest].ID
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package flight
+
+import "testing"
+
+func TestApplyAlternatives(t *testing.T) {
+	seats := func(id string, price uint64, available uint32) Flight {
+		return Flight{ID: id, Price: Price{Amount: price, Currency: "IDR"}, AvailableSeats: available}
+	}
+
+	tests := []struct {
+		name       string
+		flights    []Flight
+		passengers uint32
+		want       map[string]string // flight ID -> AlternativeFlightID
+	}{
+		{
+			name:       "cheapest is sold out",
+			flights:    []Flight{seats("QZ520", 650000, 0), seats("JT740", 950000, 10), seats("GA400", 1250000, 20)},
+			passengers: 2,
+			want:       map[string]string{"QZ520": "JT740", "JT740": "", "GA400": ""},
+		},
+		{
+			name:       "under capacity points past cheaper viable ones too",
+			flights:    []Flight{seats("GA400", 1250000, 1), seats("QZ520", 650000, 3), seats("JT740", 950000, 2)},
+			passengers: 3,
+			want:       map[string]string{"GA400": "QZ520", "QZ520": "", "JT740": "QZ520"},
+		},
+		{
+			name:       "exactly enough seats is viable",
+			flights:    []Flight{seats("QZ520", 650000, 2), seats("JT740", 950000, 1)},
+			passengers: 2,
+			want:       map[string]string{"QZ520": "", "JT740": "QZ520"},
+		},
+		{
+			name:       "nothing can seat everyone",
+			flights:    []Flight{seats("QZ520", 650000, 1), seats("JT740", 950000, 0)},
+			passengers: 4,
+			want:       map[string]string{"QZ520": "", "JT740": ""},
+		},
+		{
+			name:       "every flight has room",
+			flights:    []Flight{seats("QZ520", 650000, 9), seats("JT740", 950000, 9)},
+			passengers: 1,
+			want:       map[string]string{"QZ520": "", "JT740": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyAlternatives(tt.flights, tt.passengers)
+
+			if len(got) != len(tt.flights) {
+				t.Fatalf("expected %d flights, got %d", len(tt.flights), len(got))
+			}
+			for i, f := range got {
+				if f.ID != tt.flights[i].ID {
+					t.Errorf("expected order to be kept, got %s at %d", f.ID, i)
+				}
+				if f.AlternativeFlightID != tt.want[f.ID] {
+					t.Errorf("%s: expected alternative %q, got %q", f.ID, tt.want[f.ID], f.AlternativeFlightID)
+				}
+				if tt.flights[i].AlternativeFlightID != "" {
+					t.Errorf("expected input slice to stay untouched")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package flight
+
+import "strings"
+
+// AncillaryFee is the estimated per passenger cost of extras that are not part of the fare
+type AncillaryFee struct {
+	Seat    uint64
+	Baggage uint64
+}
+
+// WithAncillaryFees sets the fee estimates per cabin class (economy, business, ...).
+// Cabin classes without an entry are estimated without any fees.
+func WithAncillaryFees(fees map[string]AncillaryFee) ServiceOption {
+	return func(s *Service) {
+		s.ancillaryFees = make(map[string]AncillaryFee, len(fees))
+		for cabin, fee := range fees {
+			s.ancillaryFees[strings.ToLower(cabin)] = fee
+		}
+	}
+}
+
+// applyEstimatedTotals returns a copy of flights with EstimatedTotal set.
+//
+// Assumptions:
+//   - Price.Amount is the all-in fare for ONE passenger, taxes included (what every provider returns today)
+//   - Seat and baggage fees are charged per passenger and are in the same currency as the fare
+//   - Fees only depend on the cabin class, not on the airline or route
+//
+// EstimatedTotal = (fare + seat fee + baggage fee) * passengers
+func (s *Service) applyEstimatedTotals(flights []Flight, passengers uint32) []Flight {
+	// Copy so we never write into a slice that is still being marshaled for the cache
+	estimated := make([]Flight, len(flights))
+	copy(estimated, flights)
+
+	for i := range estimated {
+		fee := s.ancillaryFees[strings.ToLower(estimated[i].CabinClass)]
+		perPassenger := estimated[i].Price.Amount + fee.Seat + fee.Baggage
+
+		estimated[i].EstimatedTotal = &Price{
+			Amount:   perPassenger * uint64(passengers),
+			Currency: estimated[i].Price.Currency,
+		}
+	}
+	return estimated
+}
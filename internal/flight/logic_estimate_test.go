@@ -0,0 +1,46 @@
+package flight
+
+import "testing"
+
+func TestApplyEstimatedTotals(t *testing.T) {
+	s := NewService(nil, nil, 0, nil, WithAncillaryFees(map[string]AncillaryFee{
+		"economy":  {Seat: 50000, Baggage: 150000},
+		"Business": {Seat: 0, Baggage: 0},
+	}))
+
+	flights := []Flight{
+		{ID: "economy", CabinClass: "ECONOMY", Price: Price{Amount: 1000000, Currency: "IDR"}},
+		{ID: "business", CabinClass: "business", Price: Price{Amount: 4000000, Currency: "IDR"}},
+		{ID: "unknown", CabinClass: "first", Price: Price{Amount: 9000000, Currency: "IDR"}},
+	}
+
+	tests := []struct {
+		name       string
+		passengers uint32
+		want       []uint64
+	}{
+		{name: "single passenger", passengers: 1, want: []uint64{1200000, 4000000, 9000000}},
+		{name: "family of three", passengers: 3, want: []uint64{3600000, 12000000, 27000000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.applyEstimatedTotals(flights, tt.passengers)
+			for i, f := range got {
+				if f.EstimatedTotal == nil {
+					t.Fatalf("%s: expected estimated total to be set", f.ID)
+				}
+				if f.EstimatedTotal.Amount != tt.want[i] {
+					t.Errorf("%s: expected %d, got %d", f.ID, tt.want[i], f.EstimatedTotal.Amount)
+				}
+				if f.EstimatedTotal.Currency != "IDR" {
+					t.Errorf("%s: expected IDR, got %s", f.ID, f.EstimatedTotal.Currency)
+				}
+			}
+		})
+	}
+
+	if flights[0].EstimatedTotal != nil {
+		t.Errorf("expected input flights to stay untouched")
+	}
+}
@@ -35,9 +35,20 @@ func (s *Service) applyFilters(flights []Flight, opts FilterOptions) []Flight {
 	filtered := make([]Flight, 0, len(flights))
 
 	for _, f := range flights {
-		if fc.matches(f) {
-			filtered = append(filtered, f)
+		if !fc.matches(f) {
+			continue
 		}
+		if opts.SeatsTogether != nil {
+			fits, confirmed := canSeatTogether(f, *opts.SeatsTogether)
+			if !fits {
+				continue
+			}
+			if confirmed {
+				// f is a copy, the cached flight is left untouched
+				f.SeatsTogetherAvailable = &confirmed
+			}
+		}
+		filtered = append(filtered, f)
 	}
 
 	return filtered
@@ -98,6 +109,16 @@ func (fc *filterContext) matches(f Flight) bool {
 	return true
 }
 
+// canSeatTogether uses the provider's adjacency data when there is some (confirmed=true),
+// otherwise it can only check that enough seats are left at all
+func canSeatTogether(f Flight, group uint32) (fits bool, confirmed bool) {
+	if f.MaxSeatsTogether != nil {
+		fits = *f.MaxSeatsTogether >= group
+		return fits, fits
+	}
+	return f.AvailableSeats >= group, false
+}
+
 // Helper functions for time conversion
 func parseTimeToSeconds(timeStr string) int64 {
 	t, err := time.Parse("15:04", timeStr)
@@ -0,0 +1,72 @@
+package flight
+
+import "testing"
+
+func uint32Ptr(v uint32) *uint32 { return &v }
+
+func TestApplyFilters_SeatsTogether(t *testing.T) {
+	s := newTestService(newMockClient(t), nil)
+
+	withAdjacency := func(f Flight, seats, together uint32) Flight {
+		f.AvailableSeats = seats
+		f.MaxSeatsTogether = uint32Ptr(together)
+		return f
+	}
+	withoutAdjacency := func(f Flight, seats uint32) Flight {
+		f.AvailableSeats = seats
+		return f
+	}
+	flights := []Flight{
+		withAdjacency(testFlight("GA400", 1250000, 110, 0, "Garuda Indonesia"), 28, 4),
+		withAdjacency(testFlight("GA410", 1300000, 115, 0, "Garuda Indonesia"), 28, 2),
+		withoutAdjacency(testFlight("QZ520", 650000, 160, 1, "AirAsia"), 67),
+		withoutAdjacency(testFlight("JT740", 950000, 105, 0, "Lion Air"), 3),
+	}
+
+	tests := []struct {
+		name          string
+		group         uint32
+		wantIDs       []string
+		wantConfirmed map[string]bool
+	}{
+		{
+			name:          "adjacency data filters, seat count is the fallback",
+			group:         4,
+			wantIDs:       []string{"GA400", "QZ520"},
+			wantConfirmed: map[string]bool{"GA400": true},
+		},
+		{
+			name:          "small group fits everywhere",
+			group:         2,
+			wantIDs:       []string{"GA400", "GA410", "QZ520", "JT740"},
+			wantConfirmed: map[string]bool{"GA400": true, "GA410": true},
+		},
+		{
+			name:    "nothing seats a large group",
+			group:   70,
+			wantIDs: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.applyFilters(flights, FilterOptions{SeatsTogether: uint32Ptr(tt.group)})
+
+			if !equalIDs(flightIDs(got), tt.wantIDs) {
+				t.Fatalf("expected %v, got %v", tt.wantIDs, flightIDs(got))
+			}
+			for _, f := range got {
+				confirmed := f.SeatsTogetherAvailable != nil && *f.SeatsTogetherAvailable
+				if confirmed != tt.wantConfirmed[f.ID] {
+					t.Errorf("%s: expected seats_together_available=%v, got %v", f.ID, tt.wantConfirmed[f.ID], f.SeatsTogetherAvailable)
+				}
+			}
+		})
+	}
+
+	for _, f := range flights {
+		if f.SeatsTogetherAvailable != nil {
+			t.Errorf("%s: filter must not modify the input flights", f.ID)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	minMultiCityLegs = 2
+	// defaultMaxMultiCityLegs applies unless the service is built WithMaxMultiCityLegs
+	defaultMaxMultiCityLegs = 5
+)
+
+// WithMaxMultiCityLegs caps the number of legs in a multi-city search. Values below 2 are ignored.
+func WithMaxMultiCityLegs(n int) ServiceOption {
+	return func(s *Service) {
+		if n >= minMultiCityLegs {
+			s.maxMultiCityLegs = n
+		}
+	}
+}
+
+// Validate checks the itinerary with the default leg limit
+func (r MultiCityRequest) Validate() error {
+	return r.validate(defaultMaxMultiCityLegs)
+}
+
+// validate rejects itineraries that can't be flown in order. Errors name the offending field, e.g. legs[2].departure_date.
+func (r MultiCityRequest) validate(maxLegs int) error {
+	if len(r.Legs) < minMultiCityLegs {
+		return NewFieldValidationError(ErrorCodeValidation, "legs",
+			fmt.Sprintf("legs must contain at least %d entries", minMultiCityLegs))
+	}
+	if len(r.Legs) > maxLegs {
+		return NewFieldValidationError(ErrorCodeTooManyLegs, "legs",
+			fmt.Sprintf("legs must contain at most %d entries, got %d", maxLegs, len(r.Legs)))
+	}
+
+	for i, leg := range r.Legs {
+		if err := leg.Validate(); err != nil {
+			var validationErr *ValidationError
+			if errors.As(err, &validationErr) {
+				return NewFieldValidationError(validationErr.Code(), fmt.Sprintf("legs[%d]", i),
+					fmt.Sprintf("leg %d: %s", i+1, validationErr.Message))
+			}
+			return fmt.Errorf("leg %d: %w", i+1, err)
+		}
+	}
+
+	for i := 0; i < len(r.Legs)-1; i++ {
+		current, next := r.Legs[i], r.Legs[i+1]
+
+		// Leg N has to land where leg N+1 takes off
+		if !strings.EqualFold(current.Destination, next.Origin) {
+			return NewFieldValidationError(ErrorCodeInvalidItinerary, fmt.Sprintf("legs[%d].origin", i+1),
+				fmt.Sprintf("leg %d destination %s does not match leg %d origin %s",
+					i+1, current.Destination, i+2, next.Origin))
+		}
+
+		// Same day is fine (morning CGK-SIN, evening SIN-BKK), going back in time is not.
+		// Dates are already validated as YYYY-MM-DD, which compares correctly as strings.
+		if next.DepartureDate < current.DepartureDate {
+			return NewFieldValidationError(ErrorCodeLegDatesOutOfOrder, fmt.Sprintf("legs[%d].departure_date", i+1),
+				fmt.Sprintf("leg %d departs %s, before leg %d on %s",
+					i+2, next.DepartureDate, i+1, current.DepartureDate))
+		}
+	}
+
+	return nil
+}
+
+// SearchMultiCity searches every leg in parallel. Each leg goes through getOrFetchFlights
+// so it hits the cache on its own, and the providers are still queried in parallel per leg.
+func (s *Service) SearchMultiCity(ctx context.Context, req MultiCityRequest) (*MultiCityResponse, error) {
+	if err := req.validate(s.maxMultiCityLegs); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	legs := make([]FlightSearchResponse, len(req.Legs))
+	errs := make([]error, len(req.Legs))
+
+	var wg sync.WaitGroup
+	for i, leg := range req.Legs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			flights, metadata, err := s.getOrFetchFlights(ctx, s.requestLogger(ctx, leg), leg)
+			if err != nil {
+				errs[i] = fmt.Errorf("leg %d: %w", i+1, err)
+				return
+			}
+			legs[i] = FlightSearchResponse{
+				SearchCriteria: leg,
+				Metadata:       metadata,
+				Flights:        applyAlternatives(s.applyEstimatedTotals(flights, leg.Passengers), leg.Passengers),
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &MultiCityResponse{
+		Legs:          legs,
+		TotalMinPrice: totalMinPrice(legs),
+	}, nil
+}
+
+// totalMinPrice sums the cheapest fare of every leg.
+// If any leg has no flights the itinerary can't be booked, so the total stays zero.
+func totalMinPrice(legs []FlightSearchResponse) Price {
+	var total Price
+	for _, leg := range legs {
+		if len(leg.Flights) == 0 {
+			return Price{}
+		}
+
+		cheapest := leg.Flights[0].Price
+		for _, f := range leg.Flights[1:] {
+			if f.Price.Amount < cheapest.Amount {
+				cheapest = f.Price
+			}
+		}
+
+		total.Amount += cheapest.Amount
+		if total.Currency == "" {
+			total.Currency = cheapest.Currency
+		}
+	}
+	return total
+}
@@ -0,0 +1,137 @@
+package flight
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiCityRequest_Validate(t *testing.T) {
+	leg := func(origin, destination string) SearchRequest {
+		return SearchRequest{Origin: origin, Destination: destination, DepartureDate: "2099-01-01", Passengers: 1}
+	}
+	legOn := func(origin, destination, date string) SearchRequest {
+		l := leg(origin, destination)
+		l.DepartureDate = date
+		return l
+	}
+
+	tests := []struct {
+		name      string
+		legs      []SearchRequest
+		maxLegs   int
+		wantCode  ErrorCode
+		wantField string
+	}{
+		{
+			name: "valid three legs",
+			legs: []SearchRequest{leg("CGK", "SIN"), leg("SIN", "BKK"), leg("BKK", "CGK")},
+		},
+		{
+			name:     "single leg",
+			legs:     []SearchRequest{leg("CGK", "SIN")},
+			wantCode: ErrorCodeValidation,
+		},
+		{
+			name: "six legs",
+			legs: []SearchRequest{
+				leg("CGK", "SIN"), leg("SIN", "BKK"), leg("BKK", "KUL"),
+				leg("KUL", "DPS"), leg("DPS", "SUB"), leg("SUB", "CGK"),
+			},
+			wantCode:  ErrorCodeTooManyLegs,
+			wantField: "legs",
+		},
+		{
+			name:      "over a configured max",
+			legs:      []SearchRequest{leg("CGK", "SIN"), leg("SIN", "BKK"), leg("BKK", "KUL")},
+			maxLegs:   2,
+			wantCode:  ErrorCodeTooManyLegs,
+			wantField: "legs",
+		},
+		{
+			name:    "at a configured max",
+			legs:    []SearchRequest{leg("CGK", "SIN"), leg("SIN", "BKK"), leg("BKK", "KUL")},
+			maxLegs: 3,
+		},
+		{
+			name: "dates going forward",
+			legs: []SearchRequest{legOn("CGK", "SIN", "2099-01-01"), legOn("SIN", "BKK", "2099-01-03")},
+		},
+		{
+			name: "same day connection",
+			legs: []SearchRequest{legOn("CGK", "SIN", "2099-01-01"), legOn("SIN", "BKK", "2099-01-01")},
+		},
+		{
+			name:      "leg departs before the previous one",
+			legs:      []SearchRequest{legOn("CGK", "SIN", "2099-01-05"), legOn("SIN", "BKK", "2099-01-04")},
+			wantCode:  ErrorCodeLegDatesOutOfOrder,
+			wantField: "legs[1].departure_date",
+		},
+		{
+			name: "backtrack in the middle of the trip",
+			legs: []SearchRequest{
+				legOn("CGK", "SIN", "2099-01-01"), legOn("SIN", "BKK", "2099-01-05"), legOn("BKK", "CGK", "2099-01-03"),
+			},
+			wantCode:  ErrorCodeLegDatesOutOfOrder,
+			wantField: "legs[2].departure_date",
+		},
+		{
+			name:      "disconnected legs",
+			legs:      []SearchRequest{leg("CGK", "SIN"), leg("KUL", "BKK")},
+			wantCode:  ErrorCodeInvalidItinerary,
+			wantField: "legs[1].origin",
+		},
+		{
+			name:      "invalid leg",
+			legs:      []SearchRequest{leg("CGK", "SIN"), leg("SIN", "SIN")},
+			wantCode:  ErrorCodeSameOriginDestination,
+			wantField: "legs[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxLegs := tt.maxLegs
+			if maxLegs == 0 {
+				maxLegs = defaultMaxMultiCityLegs
+			}
+			err := MultiCityRequest{Legs: tt.legs}.validate(maxLegs)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+			if validationErr.Code() != tt.wantCode {
+				t.Errorf("expected code %s, got %s", tt.wantCode, validationErr.Code())
+			}
+			if tt.wantField != "" && validationErr.Field != tt.wantField {
+				t.Errorf("expected field %q, got %q", tt.wantField, validationErr.Field)
+			}
+		})
+	}
+}
+
+func TestTotalMinPrice(t *testing.T) {
+	flightWithPrice := func(amount uint64) Flight {
+		return Flight{Price: Price{Amount: amount, Currency: "IDR"}}
+	}
+
+	legs := []FlightSearchResponse{
+		{Flights: []Flight{flightWithPrice(900000), flightWithPrice(750000)}},
+		{Flights: []Flight{flightWithPrice(1200000)}},
+	}
+	got := totalMinPrice(legs)
+	if got.Amount != 1950000 || got.Currency != "IDR" {
+		t.Errorf("expected 1950000 IDR, got %d %s", got.Amount, got.Currency)
+	}
+
+	legs = append(legs, FlightSearchResponse{})
+	if got := totalMinPrice(legs); got.Amount != 0 {
+		t.Errorf("expected zero total when a leg has no flights, got %d", got.Amount)
+	}
+}
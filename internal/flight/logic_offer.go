@@ -0,0 +1,136 @@
+package flight
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response formats accepted in ?format=
+const (
+	FormatNative = "native"
+	FormatOffer  = "offer"
+)
+
+// OfferResponse is an NDC-like view of a search: offers → itineraries → segments, with fares per offer.
+// It carries the same data as FlightSearchResponse, only reshaped for downstream systems.
+type OfferResponse struct {
+	Metadata       Metadata      `json:"metadata"`
+	SearchCriteria SearchRequest `json:"search_criteria"`
+	Offers         []Offer       `json:"offers"`
+}
+
+type Offer struct {
+	OfferID     string      `json:"offer_id"`
+	Provider    string      `json:"provider"`
+	Owner       string      `json:"owner"` // airline code of the carrier selling the offer
+	OfferToken  string      `json:"offer_token,omitempty"`
+	TotalPrice  Price       `json:"total_price"`
+	Itineraries []Itinerary `json:"itineraries"`
+	Fares       []OfferFare `json:"fares"`
+}
+
+type Itinerary struct {
+	ItineraryID     string         `json:"itinerary_id"`
+	DurationMinutes uint32         `json:"duration_minutes"`
+	Segments        []OfferSegment `json:"segments"`
+}
+
+type OfferSegment struct {
+	SegmentID        string        `json:"segment_id"`
+	MarketingCarrier Airline       `json:"marketing_carrier"`
+	FlightNumber     string        `json:"flight_number"`
+	Departure        OfferLocation `json:"departure"`
+	Arrival          OfferLocation `json:"arrival"`
+	DurationMinutes  uint32        `json:"duration_minutes"`
+	Stops            uint32        `json:"stops"`
+	Aircraft         string        `json:"aircraft,omitempty"`
+}
+
+type OfferLocation struct {
+	Airport string    `json:"airport"`
+	City    string    `json:"city"`
+	At      time.Time `json:"at"`
+}
+
+// OfferFare is the per passenger fare, SegmentRefs points at the segments it covers
+type OfferFare struct {
+	PassengerType  string   `json:"passenger_type"`
+	CabinClass     string   `json:"cabin_class"`
+	Price          Price    `json:"price"`
+	SeatsAvailable uint32   `json:"seats_available"`
+	Baggage        Baggage  `json:"baggage"`
+	SegmentRefs    []string `json:"segment_refs"`
+}
+
+// adultPassengerType is the only passenger type searches support today
+const adultPassengerType = "ADT"
+
+// ToOfferResponse maps a search response into the offer envelope.
+// Providers give us one segment per flight (stops are not broken down), so every itinerary has exactly one segment.
+func ToOfferResponse(resp *FlightSearchResponse) *OfferResponse {
+	offers := make([]Offer, 0, len(resp.Flights))
+	for _, f := range resp.Flights {
+		offers = append(offers, toOffer(f))
+	}
+	return &OfferResponse{
+		Metadata:       resp.Metadata,
+		SearchCriteria: resp.SearchCriteria,
+		Offers:         offers,
+	}
+}
+
+func toOffer(f Flight) Offer {
+	segmentID := f.ID + "-SEG1"
+	total := f.Price
+	if f.EstimatedTotal != nil {
+		total = *f.EstimatedTotal
+	}
+
+	return Offer{
+		OfferID:    f.ID,
+		Provider:   f.Provider,
+		Owner:      f.Airline.Code,
+		OfferToken: f.OfferToken,
+		TotalPrice: total,
+		Itineraries: []Itinerary{{
+			ItineraryID:     f.ID + "-ITIN1",
+			DurationMinutes: f.Duration.TotalMinutes,
+			Segments: []OfferSegment{{
+				SegmentID:        segmentID,
+				MarketingCarrier: f.Airline,
+				FlightNumber:     f.FlightNumber,
+				Departure:        OfferLocation{Airport: f.Departure.Airport, City: f.Departure.City, At: f.Departure.Datetime},
+				Arrival:          OfferLocation{Airport: f.Arrival.Airport, City: f.Arrival.City, At: f.Arrival.Datetime},
+				DurationMinutes:  f.Duration.TotalMinutes,
+				Stops:            f.Stops,
+				Aircraft:         f.Aircraft,
+			}},
+		}},
+		Fares: []OfferFare{{
+			PassengerType:  adultPassengerType,
+			CabinClass:     f.CabinClass,
+			Price:          f.Price,
+			SeatsAvailable: f.AvailableSeats,
+			Baggage:        f.Baggage,
+			SegmentRefs:    []string{segmentID},
+		}},
+	}
+}
+
+// writeFlights renders resp in the format asked for by ?format=, native when empty
+func writeFlights(c *gin.Context, resp *FlightSearchResponse) {
+	switch format := c.DefaultQuery("format", FormatNative); format {
+	case FormatNative:
+		c.JSON(http.StatusOK, resp)
+	case FormatOffer:
+		c.JSON(http.StatusOK, ToOfferResponse(resp))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("unknown format %q, expected %s or %s", format, FormatNative, FormatOffer),
+			"code":  ErrorCodeValidation,
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package flight
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata golden files")
+
+func offerFixture() *FlightSearchResponse {
+	wib := time.FixedZone("WIB", 7*60*60)
+	return &FlightSearchResponse{
+		SearchCriteria: SearchRequest{
+			Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 2, CabinClass: "economy",
+		},
+		Metadata: Metadata{TotalResults: 2, ProvidersQueried: 4, ProvidersSucceeded: 4, CacheHit: true},
+		Flights: []Flight{
+			{
+				ID:           "GA400_Garuda Indonesia",
+				Provider:     "Garuda Indonesia",
+				Airline:      Airline{Name: "Garuda Indonesia", Code: "GA"},
+				FlightNumber: "GA400",
+				Departure: LocationTime{Airport: "CGK", City: "Jakarta",
+					Datetime: time.Date(2025, 12, 15, 6, 0, 0, 0, wib)},
+				Arrival: LocationTime{Airport: "DPS", City: "Denpasar",
+					Datetime: time.Date(2025, 12, 15, 8, 50, 0, 0, wib)},
+				Duration:       Duration{TotalMinutes: 110, Formatted: "1h 50m"},
+				Price:          Price{Amount: 1250000, Currency: "IDR"},
+				AvailableSeats: 28,
+				CabinClass:     "economy",
+				Aircraft:       "Boeing 737-800",
+				Baggage:        Baggage{CarryOn: "7 kg", Checked: "20 kg"},
+				EstimatedTotal: &Price{Amount: 2500000, Currency: "IDR"},
+				OfferToken:     "ga-offer-123",
+			},
+			{
+				ID:           "JT740_Lion Air",
+				Provider:     "Lion Air",
+				Airline:      Airline{Name: "Lion Air", Code: "JT"},
+				FlightNumber: "JT740",
+				Departure: LocationTime{Airport: "CGK", City: "Jakarta",
+					Datetime: time.Date(2025, 12, 15, 5, 30, 0, 0, wib)},
+				Arrival: LocationTime{Airport: "DPS", City: "Denpasar",
+					Datetime: time.Date(2025, 12, 15, 10, 15, 0, 0, wib)},
+				Duration:       Duration{TotalMinutes: 225, Formatted: "3h 45m"},
+				Stops:          1,
+				Price:          Price{Amount: 950000, Currency: "IDR"},
+				AvailableSeats: 45,
+				CabinClass:     "economy",
+				Baggage:        Baggage{CarryOn: "7 kg", Checked: "20 kg"},
+			},
+		},
+	}
+}
+
+func TestToOfferResponse_Golden(t *testing.T) {
+	got, err := json.MarshalIndent(ToOfferResponse(offerFixture()), "", "  ")
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "offer_response.golden.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("offer envelope differs from %s, run with -update if the change is intended\ngot:\n%s", golden, got)
+	}
+}
+
+func TestToOfferResponse_Empty(t *testing.T) {
+	got := ToOfferResponse(&FlightSearchResponse{})
+	if got.Offers == nil || len(got.Offers) != 0 {
+		t.Errorf("expected an empty, non-nil offer list, got %#v", got.Offers)
+	}
+}
+
+func TestWriteFlights_Format(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantKey    string
+	}{
+		{name: "default is native", query: "", wantStatus: http.StatusOK, wantKey: "flights"},
+		{name: "explicit native", query: "?format=native", wantStatus: http.StatusOK, wantKey: "flights"},
+		{name: "offer envelope", query: "?format=offer", wantStatus: http.StatusOK, wantKey: "offers"},
+		{name: "unknown format", query: "?format=ndc", wantStatus: http.StatusBadRequest, wantKey: "code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/flights/search"+tt.query, nil)
+
+			writeFlights(c, offerFixture())
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if _, ok := body[tt.wantKey]; !ok {
+				t.Errorf("expected %q in response, got %s", tt.wantKey, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package flight
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Validate checks the page window, a zero limit means "use the default"
+func (p PageRequest) Validate() error {
+	if p.Limit > maxPageLimit {
+		return NewFieldValidationError(ErrorCodeValidation, "page.limit", "page.limit must be between 1 and 100")
+	}
+	return nil
+}
+
+// applyPagination cuts the page out of the already sorted flights and describes it
+func applyPagination(flights []Flight, page PageRequest) ([]Flight, PageMetadata) {
+	limit := page.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	total := uint32(len(flights))
+	meta := PageMetadata{TotalResults: total, Limit: limit, Offset: page.Offset}
+	if page.Offset >= total {
+		return []Flight{}, meta
+	}
+
+	end := min(page.Offset+limit, total)
+	meta.HasNextPage = end < total
+	return flights[page.Offset:end], meta
+}
@@ -0,0 +1,147 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"travel/pkg/cache"
+)
+
+func TestApplyPagination(t *testing.T) {
+	flights := make([]Flight, 45)
+	for i := range flights {
+		flights[i] = Flight{ID: fmt.Sprintf("F%02d", i)}
+	}
+
+	tests := []struct {
+		name      string
+		page      PageRequest
+		wantFirst string
+		wantLen   int
+		wantMeta  PageMetadata
+	}{
+		{
+			name:      "defaults to first 20",
+			page:      PageRequest{},
+			wantFirst: "F00",
+			wantLen:   20,
+			wantMeta:  PageMetadata{TotalResults: 45, Limit: 20, Offset: 0, HasNextPage: true},
+		},
+		{
+			name:      "middle page",
+			page:      PageRequest{Limit: 10, Offset: 10},
+			wantFirst: "F10",
+			wantLen:   10,
+			wantMeta:  PageMetadata{TotalResults: 45, Limit: 10, Offset: 10, HasNextPage: true},
+		},
+		{
+			name:      "last page is partial",
+			page:      PageRequest{Limit: 20, Offset: 40},
+			wantFirst: "F40",
+			wantLen:   5,
+			wantMeta:  PageMetadata{TotalResults: 45, Limit: 20, Offset: 40, HasNextPage: false},
+		},
+		{
+			name:      "last page ends exactly on the total",
+			page:      PageRequest{Limit: 15, Offset: 30},
+			wantFirst: "F30",
+			wantLen:   15,
+			wantMeta:  PageMetadata{TotalResults: 45, Limit: 15, Offset: 30, HasNextPage: false},
+		},
+		{
+			name:     "offset at the total",
+			page:     PageRequest{Limit: 10, Offset: 45},
+			wantLen:  0,
+			wantMeta: PageMetadata{TotalResults: 45, Limit: 10, Offset: 45, HasNextPage: false},
+		},
+		{
+			name:     "offset beyond results",
+			page:     PageRequest{Limit: 10, Offset: 500},
+			wantLen:  0,
+			wantMeta: PageMetadata{TotalResults: 45, Limit: 10, Offset: 500, HasNextPage: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta := applyPagination(flights, tt.page)
+			if len(got) != tt.wantLen {
+				t.Fatalf("expected %d flights, got %d", tt.wantLen, len(got))
+			}
+			if tt.wantLen > 0 && got[0].ID != tt.wantFirst {
+				t.Errorf("expected page to start at %s, got %s", tt.wantFirst, got[0].ID)
+			}
+			if got == nil {
+				t.Errorf("expected an empty slice, got nil")
+			}
+			if meta != tt.wantMeta {
+				t.Errorf("expected %+v, got %+v", tt.wantMeta, meta)
+			}
+		})
+	}
+}
+
+func TestPageRequest_Validate(t *testing.T) {
+	tests := []struct {
+		limit   uint32
+		wantErr bool
+	}{
+		{limit: 0},
+		{limit: 1},
+		{limit: 100},
+		{limit: 101, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.limit), func(t *testing.T) {
+			err := PageRequest{Limit: tt.limit}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			var vErr *ValidationError
+			if tt.wantErr && (!errors.As(err, &vErr) || vErr.Field != "page.limit") {
+				t.Errorf("expected a page.limit validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestService_FilterFlights_Page(t *testing.T) {
+	client := newMockClient(t)
+	expectSearch(client, testSearchRequest, FlightSearchResponse{
+		Flights:  testFlights(),
+		Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+	})
+	s := newTestService(client, cache.NewMemoryCache())
+
+	resp, err := s.FilterFlights(context.Background(), FilterRequest{
+		SearchRequest: testSearchRequest,
+		Sort:          &SortOptions{By: "price", Order: "asc"},
+		Page:          PageRequest{Limit: 2, Offset: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := flightIDs(resp.Flights); !equalIDs(got, []string{"JT740", "GA400"}) {
+		t.Errorf("expected [JT740 GA400], got %v", got)
+	}
+	if resp.Metadata.TotalResults != 3 {
+		t.Errorf("expected total_results to count every match, got %d", resp.Metadata.TotalResults)
+	}
+	want := PageMetadata{TotalResults: 3, Limit: 2, Offset: 1, HasNextPage: false}
+	if resp.Metadata.Page == nil || *resp.Metadata.Page != want {
+		t.Errorf("expected page %+v, got %+v", want, resp.Metadata.Page)
+	}
+
+	_, err = s.FilterFlights(context.Background(), FilterRequest{
+		SearchRequest: testSearchRequest,
+		Page:          PageRequest{Limit: 101},
+	})
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		t.Errorf("expected a validation error for limit 101, got %v", err)
+	}
+}
@@ -32,7 +32,13 @@ func (s *Service) applySorting(flights []Flight, sortOpt SortOptions) []Flight {
 	case "arrival_time":
 		s.sortByArrivalTime(sorted, sortOpt.Order)
 	case "best_value":
-		s.sortByBestValue(sorted, sortOpt.Order)
+		s.sortByBestValue(sorted, sortOpt.Order, nil)
+	case "weighted_best_value":
+		weights := s.providerWeights
+		if weights == nil {
+			weights = map[string]float64{}
+		}
+		s.sortByBestValue(sorted, sortOpt.Order, weights)
 	default:
 		s.logger.Warn("invalid_sort_criteria", logger.Field{Key: "sort_by", Value: sortOpt.By})
 	}
@@ -77,7 +83,8 @@ func (s *Service) sortByArrivalTime(flights []Flight, order string) {
 	})
 }
 
-func (s *Service) sortByBestValue(flights []Flight, order string) {
+// sortByBestValue sorts by BestValueScore, biased by provider weights when weights is not nil
+func (s *Service) sortByBestValue(flights []Flight, order string, weights map[string]float64) {
 	if len(flights) <= 1 {
 		return
 	}
@@ -85,7 +92,7 @@ func (s *Service) sortByBestValue(flights []Flight, order string) {
 	// This mutates the flights by adding scores.
 	// Since 'sorted' is a deep copy of the slice structure (but shallow copy of elements),
 	// modifying *Flight fields affects the original if pointers are shared, but here Flight is a struct value in slice.
-	s.calculateBestValueScores(flights)
+	s.calculateBestValueScores(flights, weights)
 
 	sort.SliceStable(flights, func(i, j int) bool {
 		scoreI, scoreJ := 0.0, 0.0
@@ -103,7 +110,9 @@ func (s *Service) sortByBestValue(flights []Flight, order string) {
 	})
 }
 
-func (s *Service) calculateBestValueScores(flights []Flight) {
+// calculateBestValueScores sets BestValueScore on every flight.
+// With weights, each score is multiplied by (1 + weight) of its provider, providers without a weight count as 1.0.
+func (s *Service) calculateBestValueScores(flights []Flight, weights map[string]float64) {
 	var minPrice, maxPrice uint64 = math.MaxUint64, 0
 	var minDuration, maxDuration uint32 = math.MaxUint32, 0
 	var minStops, maxStops uint32 = math.MaxUint32, 0
@@ -167,6 +176,9 @@ func (s *Service) calculateBestValueScores(flights []Flight) {
 		normStops := normalize(float64(flights[i].Stops), float64(minStops), float64(maxStops))
 
 		score := (priceWeight * normPrice) + (durationWeight * normDuration) + (stopsWeight * normStops)
+		if weights != nil {
+			score *= 1 + providerWeight(weights, flights[i].Provider)
+		}
 		flights[i].BestValueScore = &score
 	}
 }
@@ -193,3 +205,23 @@ func normalize(val, min, max float64) float64 {
 	// If max == min, all flights are equal in this metric. Give them all perfect scores.
 	return 1.0
 }
+
+// defaultProviderWeight applies to providers missing from the weights map
+const defaultProviderWeight = 1.0
+
+func providerWeight(weights map[string]float64, provider string) float64 {
+	if w, ok := weights[provider]; ok {
+		return w
+	}
+	return defaultProviderWeight
+}
+
+// WithProviderWeights sets the provider bias used by the weighted_best_value sort, keyed by Flight.Provider
+func WithProviderWeights(weights map[string]float64) ServiceOption {
+	return func(s *Service) {
+		s.providerWeights = make(map[string]float64, len(weights))
+		for provider, w := range weights {
+			s.providerWeights[provider] = w
+		}
+	}
+}
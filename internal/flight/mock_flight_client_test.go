@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=mock_flight_client_test.go -package=flight -self_package=travel/internal/flight
+//
+
+// Package flight is a generated GoMock package.
+package flight
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFlightClient is a mock of FlightClient interface.
+type MockFlightClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlightClientMockRecorder
+	isgomock struct{}
+}
+
+// MockFlightClientMockRecorder is the mock recorder for MockFlightClient.
+type MockFlightClientMockRecorder struct {
+	mock *MockFlightClient
+}
+
+// NewMockFlightClient creates a new mock instance.
+func NewMockFlightClient(ctrl *gomock.Controller) *MockFlightClient {
+	mock := &MockFlightClient{ctrl: ctrl}
+	mock.recorder = &MockFlightClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlightClient) EXPECT() *MockFlightClientMockRecorder {
+	return m.recorder
+}
+
+// ProvidersHealth mocks base method.
+func (m *MockFlightClient) ProvidersHealth(ctx context.Context) []ProviderHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvidersHealth", ctx)
+	ret0, _ := ret[0].([]ProviderHealth)
+	return ret0
+}
+
+// ProvidersHealth indicates an expected call of ProvidersHealth.
+func (mr *MockFlightClientMockRecorder) ProvidersHealth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvidersHealth", reflect.TypeOf((*MockFlightClient)(nil).ProvidersHealth), ctx)
+}
+
+// SearchFlights mocks base method.
+func (m *MockFlightClient) SearchFlights(ctx context.Context, req SearchRequest) (*FlightSearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFlights", ctx, req)
+	ret0, _ := ret[0].(*FlightSearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFlights indicates an expected call of SearchFlights.
+func (mr *MockFlightClientMockRecorder) SearchFlights(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFlights", reflect.TypeOf((*MockFlightClient)(nil).SearchFlights), ctx, req)
+}
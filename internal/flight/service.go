@@ -3,85 +3,159 @@ package flight
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 	"travel/pkg/cache"
 	"travel/pkg/logger"
+
+	"golang.org/x/sync/singleflight"
 )
 
+//go:generate go run go.uber.org/mock/mockgen@v0.5.0 -source=service.go -destination=mock_flight_client_test.go -package=flight -self_package=travel/internal/flight
+
 type FlightClient interface {
 	SearchFlights(ctx context.Context, req SearchRequest) (*FlightSearchResponse, error)
+	ProvidersHealth(ctx context.Context) []ProviderHealth
 }
 
 type Service struct {
-	flightClient FlightClient
-	cache        cache.Cache
-	ttl          time.Duration
-	logger       logger.Client
+	flightClient  FlightClient
+	cache         cache.Cache
+	ttl           time.Duration
+	routeTTL      map[string]time.Duration
+	providerTTL   map[string]time.Duration
+	logger        logger.Client
+	ancillaryFees map[string]AncillaryFee
+	currencies    *CurrencySet
+
+	maxMultiCityLegs int
+	// providerWeights biases weighted_best_value, nil means every provider has the default weight
+	providerWeights map[string]float64
+	now             func() time.Time
+	// fetchGroup collapses concurrent cache misses for the same key into one provider fan-out
+	fetchGroup singleflight.Group
+}
+
+type ServiceOption func(*Service)
+
+// sharedFetchTimeout bounds a provider fan-out that no longer follows any single request's context.
+// It matches the default flights route timeout, so no caller waits on a fetch longer than it could.
+const sharedFetchTimeout = 12 * time.Second
+
+// flightCacheSchemaVersion is stored with every cached search response.
+// Bump it when cachedSearch or FlightSearchResponse changes shape so old entries are ignored after a deploy.
+const flightCacheSchemaVersion = "v2"
+
+// cachedSearch is what getOrFetchFlights stores, FetchedAt is when the providers answered
+type cachedSearch struct {
+	Response  FlightSearchResponse `json:"response"`
+	FetchedAt time.Time            `json:"fetched_at"`
 }
 
-func NewService(flightClient FlightClient, cache cache.Cache, ttlSeconds int, logger logger.Client) *Service {
-	return &Service{
+func NewService(flightClient FlightClient, cache cache.Cache, ttlSeconds int, logger logger.Client, opts ...ServiceOption) *Service {
+	s := &Service{
 		flightClient: flightClient,
 		cache:        cache,
 		ttl:          time.Duration(ttlSeconds) * time.Second,
 		logger:       logger,
+		currencies:   &CurrencySet{currencies: map[string]Currency{DefaultCurrency: isoCurrencies[DefaultCurrency]}},
+
+		maxMultiCityLegs: defaultMaxMultiCityLegs,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
+}
+
+// requestLogger is the logger for one search, every entry carries the route and the inbound trace
+func (s *Service) requestLogger(ctx context.Context, req SearchRequest) logger.Client {
+	return logger.FromContext(ctx, s.logger).WithFields(
+		logger.Field{Key: "origin", Value: req.Origin},
+		logger.Field{Key: "destination", Value: req.Destination},
+	)
 }
 
 // getOrFetchFlights is the Centralized Data Access Layer.
 // It handles Cache checking, API fetching, and background Cache setting.
-func (s *Service) getOrFetchFlights(ctx context.Context, req SearchRequest) ([]Flight, Metadata, error) {
+func (s *Service) getOrFetchFlights(ctx context.Context, log logger.Client, req SearchRequest) ([]Flight, Metadata, error) {
 	cacheKey := s.generateCacheKey(req)
 
-	cached, err := s.cache.Get(ctx, cacheKey)
-	if err == nil && cached != "" {
-		var response FlightSearchResponse
-		if err := json.Unmarshal([]byte(cached), &response); err == nil {
-			response.Metadata.CacheHit = true
-			response.Metadata.CacheKey = cacheKey
-			return response.Flights, response.Metadata, nil
-		}
-		s.logger.Error("cache_unmarshal_err", logger.Field{Key: "err", Value: err})
+	cached, found, err := cache.GetJSON[cachedSearch](ctx, s.cache, cacheKey, cache.WithSchemaVersion(flightCacheSchemaVersion))
+	if err != nil {
+		log.Error("cache_get_err", logger.ErrField(err))
 	}
-
-	// Fallback: Fetch from Provider
-	response, err := s.flightClient.SearchFlights(ctx, req)
-	if response == nil || err != nil {
-		return []Flight{}, Metadata{}, err
+	if found {
+		metadata := cached.Response.Metadata
+		metadata.CacheHit = true
+		metadata.CacheKey = cacheKey
+		metadata.DataAgeSeconds = dataAgeSeconds(cached.FetchedAt, s.now())
+		return cached.Response.Flights, metadata, nil
 	}
 
-	response.Metadata.CacheHit = false
-	response.Metadata.CacheKey = cacheKey
+	// Fallback: Fetch from Provider, once per key no matter how many requests missed at the same time.
+	// The shared fetch outlives the request that started it, so one client disconnecting doesn't fail
+	// everyone waiting on the same key. Each caller still stops waiting when its own context ends.
+	ch := s.fetchGroup.DoChan(cacheKey, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedFetchTimeout)
+		defer cancel()
+
+		response, err := s.flightClient.SearchFlights(fetchCtx, req)
+		if response == nil || err != nil {
+			return nil, err
+		}
+
+		// Cache in background (Fire and Forget)
+		// Use WithoutCancel so the cache write completes even if the HTTP request finishes early
+		bgCtx := context.WithoutCancel(ctx)
+		s.cacheFlightResponse(bgCtx, log, cacheKey, cachedSearch{Response: *response, FetchedAt: s.now()}, s.responseCacheTTL(req, response.Flights))
+		return response, nil
+	})
+
+	var res singleflight.Result
+	select {
+	case res = <-ch:
+	case <-ctx.Done():
+		return []Flight{}, Metadata{}, ctx.Err()
+	}
+	if res.Val == nil || res.Err != nil {
+		return []Flight{}, Metadata{}, res.Err
+	}
 
-	// Cache in background (Fire and Forget)
-	// Use WithoutCancel so the cache write completes even if the HTTP request finishes early
-	bgCtx := context.WithoutCancel(ctx)
-	s.cacheFlightResponse(bgCtx, cacheKey, response)
+	response := res.Val.(*FlightSearchResponse)
+	metadata := response.Metadata
+	metadata.CacheHit = false
+	metadata.CacheKey = cacheKey
 
-	return response.Flights, response.Metadata, nil
+	return response.Flights, metadata, nil
 }
 
-func (s *Service) cacheFlightResponse(ctx context.Context, key string, resp *FlightSearchResponse) {
+func (s *Service) cacheFlightResponse(ctx context.Context, log logger.Client, key string, entry cachedSearch, ttl time.Duration) {
 	go func() {
-		data, err := json.Marshal(resp)
-		if err != nil {
-			s.logger.Error("cache_marshal_err", logger.Field{Key: "err", Value: err})
-			return
-		}
-		if err := s.cache.Set(ctx, key, string(data), s.ttl); err != nil {
-			s.logger.Error("cache_set_err", logger.Field{Key: "err", Value: err})
+		if err := cache.SetJSON(ctx, s.cache, key, entry, ttl, cache.WithSchemaVersion(flightCacheSchemaVersion)); err != nil {
+			log.Error("cache_set_err", logger.ErrField(err))
 		}
 	}()
 }
 
+// dataAgeSeconds is how long ago fetchedAt was, never negative (clock skew between instances)
+func dataAgeSeconds(fetchedAt, now time.Time) uint32 {
+	age := now.Sub(fetchedAt)
+	if fetchedAt.IsZero() || age < 0 {
+		return 0
+	}
+	return uint32(age / time.Second)
+}
+
 func (s *Service) generateCacheKey(req SearchRequest) string {
-	key := fmt.Sprintf("flight:%s:%s:%s:%d:%s",
+	// ReturnDate is part of the key so a one-way result is never served for a round trip or vice versa
+	key := fmt.Sprintf("flight:%s:%s:%s:%s:%d:%s",
 		req.Origin,
 		req.Destination,
 		req.DepartureDate,
+		req.ReturnDate,
 		req.Passengers,
 		req.CabinClass,
 	)
@@ -91,42 +165,42 @@ func (s *Service) generateCacheKey(req SearchRequest) string {
 
 func (r SearchRequest) Validate() error {
 	if len(r.Origin) != 3 {
-		return NewError(ErrorCodeValidation, "origin must be a 3-letter IATA code", 400)
+		return NewValidationError(ErrorCodeValidation, "origin must be a 3-letter IATA code")
 	}
 	if len(r.Destination) != 3 {
-		return NewError(ErrorCodeValidation, "destination must be a 3-letter IATA code", 400)
+		return NewValidationError(ErrorCodeValidation, "destination must be a 3-letter IATA code")
 	}
 	if strings.EqualFold(r.Origin, r.Destination) {
-		return NewError(ErrorCodeSameOriginDestination, "origin and destination cannot be the same", 400)
+		return NewValidationError(ErrorCodeSameOriginDestination, "origin and destination cannot be the same")
 	}
 
 	if r.Passengers < 1 {
-		return NewError(ErrorCodeInvalidPassengerCount, "passengers must be at least 1", 400)
+		return NewValidationError(ErrorCodeInvalidPassengerCount, "passengers must be at least 1")
 	}
 	if r.Passengers > 9 {
-		return NewError(ErrorCodeInvalidPassengerCount, "cannot book more than 9 passengers in one search", 400)
+		return NewValidationError(ErrorCodeInvalidPassengerCount, "cannot book more than 9 passengers in one search")
 	}
 
 	const layout = "2006-01-02"
 
 	depTime, err := time.Parse(layout, r.DepartureDate)
 	if err != nil {
-		return NewError(ErrorCodeInvalidDateFormat, "invalid departure_date format, expected YYYY-MM-DD", 400)
+		return NewValidationError(ErrorCodeInvalidDateFormat, "invalid departure_date format, expected YYYY-MM-DD")
 	}
 
 	today := time.Now().Truncate(24 * time.Hour)
 	if depTime.Before(today) {
-		return NewError(ErrorCodeDeparturePast, "departure_date cannot be in the past", 400)
+		return NewValidationError(ErrorCodeDeparturePast, "departure_date cannot be in the past")
 	}
 
 	if r.ReturnDate != "" {
 		retTime, err := time.Parse(layout, r.ReturnDate)
 		if err != nil {
-			return NewError(ErrorCodeInvalidDateFormat, "invalid return_date format, expected YYYY-MM-DD", 400)
+			return NewValidationError(ErrorCodeInvalidDateFormat, "invalid return_date format, expected YYYY-MM-DD")
 		}
 
 		if retTime.Before(depTime) {
-			return NewError(ErrorCodeReturnBeforeDeparture, "return_date cannot be before departure_date", 400)
+			return NewValidationError(ErrorCodeReturnBeforeDeparture, "return_date cannot be before departure_date")
 		}
 	}
 
@@ -0,0 +1,161 @@
+package flight_test
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+	"travel/internal/flight"
+	"travel/pkg/cache"
+	"travel/pkg/logger"
+
+	"go.uber.org/mock/gomock"
+)
+
+var integrationRequest = flight.SearchRequest{
+	Origin:        "CGK",
+	Destination:   "DPS",
+	DepartureDate: "2099-12-15",
+	Passengers:    2,
+	CabinClass:    "economy",
+}
+
+func cannedFlight(id, provider string, price uint64, minutes, stops uint32, departHour int) flight.Flight {
+	departure := time.Date(2099, 12, 15, departHour, 0, 0, 0, time.UTC)
+	return flight.Flight{
+		ID:         id,
+		Provider:   provider,
+		Airline:    flight.Airline{Name: provider},
+		Departure:  flight.LocationTime{Airport: "CGK", Datetime: departure, Timestamp: departure.Unix()},
+		Arrival:    flight.LocationTime{Airport: "DPS", Datetime: departure.Add(time.Duration(minutes) * time.Minute)},
+		Duration:   flight.Duration{TotalMinutes: minutes},
+		Stops:      stops,
+		Price:      flight.Price{Amount: price, Currency: "IDR"},
+		CabinClass: "economy",
+	}
+}
+
+// withTotal is the flight as FilterFlights returns it, with the estimate for integrationRequest.Passengers
+func withTotal(f flight.Flight) flight.Flight {
+	f.EstimatedTotal = &flight.Price{Amount: f.Price.Amount * uint64(integrationRequest.Passengers), Currency: f.Price.Currency}
+	return f
+}
+
+func newIntegrationService(t *testing.T, resp *flight.FlightSearchResponse) *flight.Service {
+	t.Helper()
+	client := flight.NewMockFlightClient(gomock.NewController(t))
+	client.EXPECT().
+		SearchFlights(gomock.Any(), integrationRequest).
+		Return(resp, nil).
+		Times(1)
+	return flight.NewService(client, cache.NewMemoryCache(), 60, logger.NewWithWriter("development", io.Discard))
+}
+
+func TestService_FilterFlights_Pipeline(t *testing.T) {
+	garuda := cannedFlight("GA400", "Garuda Indonesia", 1250000, 110, 0, 6)
+	airAsia := cannedFlight("QZ520", "AirAsia", 650000, 160, 1, 9)
+	lionAir := cannedFlight("JT740", "Lion Air", 950000, 105, 0, 14)
+	batik := cannedFlight("ID6514", "Batik Air", 1100000, 115, 0, 19)
+
+	canned := func() *flight.FlightSearchResponse {
+		return &flight.FlightSearchResponse{
+			Flights:  []flight.Flight{garuda, airAsia, lionAir, batik},
+			Metadata: flight.Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+		}
+	}
+	maxStops := uint32(0)
+	maxDuration := uint32(112)
+
+	tests := []struct {
+		name    string
+		filters *flight.FilterOptions
+		sort    *flight.SortOptions
+		want    []flight.Flight
+	}{
+		{
+			name: "no filter or sort keeps provider order",
+			want: []flight.Flight{withTotal(garuda), withTotal(airAsia), withTotal(lionAir), withTotal(batik)},
+		},
+		{
+			name:    "direct flights sorted by price",
+			filters: &flight.FilterOptions{MaxStops: &maxStops},
+			sort:    &flight.SortOptions{By: "price", Order: "asc"},
+			want:    []flight.Flight{withTotal(lionAir), withTotal(batik), withTotal(garuda)},
+		},
+		{
+			name:    "price range and max duration, longest first",
+			filters: &flight.FilterOptions{PriceRange: &flight.PriceRange{Low: 900000, High: 1300000}, MaxDuration: &maxDuration},
+			sort:    &flight.SortOptions{By: "duration", Order: "desc"},
+			want:    []flight.Flight{withTotal(garuda), withTotal(lionAir)},
+		},
+		{
+			name:    "airline filter",
+			filters: &flight.FilterOptions{Airlines: []string{"AirAsia"}},
+			want:    []flight.Flight{withTotal(airAsia)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIntegrationService(t, canned())
+
+			got, err := s.FilterFlights(context.Background(), flight.FilterRequest{
+				SearchRequest: integrationRequest,
+				Filters:       tt.filters,
+				Sort:          tt.sort,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Flights, tt.want) {
+				t.Errorf("unexpected flights\ngot:  %+v\nwant: %+v", got.Flights, tt.want)
+			}
+			if got.Metadata.TotalResults != uint32(len(tt.want)) {
+				t.Errorf("expected total_results %d, got %d", len(tt.want), got.Metadata.TotalResults)
+			}
+		})
+	}
+}
+
+func TestService_FilterFlights_PartialFailure(t *testing.T) {
+	garuda := cannedFlight("GA400", "Garuda Indonesia", 1250000, 110, 0, 6)
+	lionAir := cannedFlight("JT740", "Lion Air", 950000, 105, 0, 14)
+	providerErrors := []flight.ProviderError{
+		{Provider: "AirAsia", Code: flight.ErrorCodeTimeout},
+		{Provider: "Batik Air", Code: flight.ErrorCodeProviderFailed},
+	}
+
+	s := newIntegrationService(t, &flight.FlightSearchResponse{
+		Flights: []flight.Flight{garuda, lionAir},
+		Metadata: flight.Metadata{
+			ProvidersQueried:   4,
+			ProvidersSucceeded: 2,
+			ProvidersFailed:    2,
+			ProviderErrors:     providerErrors,
+			SkippedFlights:     1,
+		},
+	})
+
+	got, err := s.FilterFlights(context.Background(), flight.FilterRequest{
+		SearchRequest: integrationRequest,
+		Sort:          &flight.SortOptions{By: "price", Order: "asc"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []flight.Flight{withTotal(lionAir), withTotal(garuda)}; !reflect.DeepEqual(got.Flights, want) {
+		t.Errorf("unexpected flights\ngot:  %+v\nwant: %+v", got.Flights, want)
+	}
+	md := got.Metadata
+	if md.ProvidersQueried != 4 || md.ProvidersSucceeded != 2 || md.ProvidersFailed != 2 {
+		t.Errorf("provider counts not propagated: %+v", md)
+	}
+	if !reflect.DeepEqual(md.ProviderErrors, providerErrors) {
+		t.Errorf("expected provider errors %+v, got %+v", providerErrors, md.ProviderErrors)
+	}
+	if md.SkippedFlights != 1 || md.TotalResults != 2 || md.CacheHit {
+		t.Errorf("unexpected metadata: %+v", md)
+	}
+}
@@ -0,0 +1,451 @@
+package flight
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"travel/pkg/cache"
+	"travel/pkg/logger"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+var testSearchRequest = SearchRequest{
+	Origin:        "CGK",
+	Destination:   "DPS",
+	DepartureDate: "2099-12-15",
+	Passengers:    1,
+	CabinClass:    "economy",
+}
+
+func testFlight(id string, price uint64, minutes uint32, stops uint32, provider string) Flight {
+	return Flight{
+		ID:       id,
+		Provider: provider,
+		Airline:  Airline{Name: provider},
+		Price:    Price{Amount: price, Currency: "IDR"},
+		Duration: Duration{TotalMinutes: minutes},
+		Stops:    stops,
+	}
+}
+
+func testFlights() []Flight {
+	return []Flight{
+		testFlight("GA400", 1250000, 110, 0, "Garuda Indonesia"),
+		testFlight("QZ520", 650000, 160, 1, "AirAsia"),
+		testFlight("JT740", 950000, 105, 0, "Lion Air"),
+	}
+}
+
+// newMockClient fails the test on any provider call that wasn't set up with EXPECT
+func newMockClient(t *testing.T) *MockFlightClient {
+	return NewMockFlightClient(gomock.NewController(t))
+}
+
+// expectSearch makes client answer req once with resp. Every call gets its own copy of the flights
+// so the service can't mutate the canned response.
+func expectSearch(client *MockFlightClient, req SearchRequest, resp FlightSearchResponse) *gomock.Call {
+	return client.EXPECT().SearchFlights(gomock.Any(), req).DoAndReturn(
+		func(context.Context, SearchRequest) (*FlightSearchResponse, error) {
+			resp := resp
+			resp.Flights = append([]Flight(nil), resp.Flights...)
+			return &resp, nil
+		})
+}
+
+func newTestService(client FlightClient, c cache.Cache) *Service {
+	return NewService(client, c, 60, logger.NewWithWriter("development", io.Discard))
+}
+
+func flightIDs(flights []Flight) []string {
+	ids := make([]string, len(flights))
+	for i, f := range flights {
+		ids[i] = f.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestService_SearchFlights(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(c cache.Cache, s *Service)
+		expect        func(client *MockFlightClient)
+		wantErr       bool
+		wantCacheHit  bool
+		wantFlights   int
+		wantSucceeded uint32
+		wantFailed    uint32
+	}{
+		{
+			name: "cache miss fetches from providers",
+			expect: func(client *MockFlightClient) {
+				expectSearch(client, testSearchRequest, FlightSearchResponse{
+					Flights:  testFlights(),
+					Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+				})
+			},
+			wantFlights:   3,
+			wantSucceeded: 4,
+		},
+		{
+			name: "cache hit skips providers",
+			setup: func(c cache.Cache, s *Service) {
+				_ = cache.SetJSON(context.Background(), c, s.generateCacheKey(testSearchRequest), cachedSearch{
+					Response: FlightSearchResponse{
+						Flights:  testFlights(),
+						Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+					},
+					FetchedAt: time.Now(),
+				}, 0, cache.WithSchemaVersion(flightCacheSchemaVersion))
+			},
+			wantCacheHit:  true,
+			wantFlights:   3,
+			wantSucceeded: 4,
+		},
+		{
+			name: "all providers fail",
+			expect: func(client *MockFlightClient) {
+				expectSearch(client, testSearchRequest, FlightSearchResponse{
+					Metadata: Metadata{
+						ProvidersQueried: 4,
+						ProvidersFailed:  4,
+						ProviderErrors: []ProviderError{
+							{Provider: "AirAsia", Code: ErrorCodeTimeout},
+							{Provider: "Batik Air", Code: ErrorCodeProviderFailed},
+							{Provider: "Garuda Indonesia", Code: ErrorCodeProviderFailed},
+							{Provider: "Lion Air", Code: ErrorCodeProviderFailed},
+						},
+					},
+				})
+			},
+			wantFailed: 4,
+		},
+		{
+			name: "partial provider failure keeps available results",
+			expect: func(client *MockFlightClient) {
+				expectSearch(client, testSearchRequest, FlightSearchResponse{
+					Flights: testFlights()[:2],
+					Metadata: Metadata{
+						ProvidersQueried:   4,
+						ProvidersSucceeded: 3,
+						ProvidersFailed:    1,
+						ProviderErrors:     []ProviderError{{Provider: "Lion Air", Code: ErrorCodeTimeout}},
+					},
+				})
+			},
+			wantFlights:   2,
+			wantSucceeded: 3,
+			wantFailed:    1,
+		},
+		{
+			name: "client error is returned",
+			expect: func(client *MockFlightClient) {
+				client.EXPECT().SearchFlights(gomock.Any(), testSearchRequest).Return(nil, context.DeadlineExceeded)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without expect, any provider call fails the test
+			client := newMockClient(t)
+			if tt.expect != nil {
+				tt.expect(client)
+			}
+			c := cache.NewMemoryCache()
+			s := newTestService(client, c)
+			if tt.setup != nil {
+				tt.setup(c, s)
+			}
+
+			resp, err := s.SearchFlights(context.Background(), testSearchRequest)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(resp.Flights) != tt.wantFlights {
+				t.Errorf("expected %d flights, got %d", tt.wantFlights, len(resp.Flights))
+			}
+			if resp.Metadata.CacheHit != tt.wantCacheHit {
+				t.Errorf("expected cache_hit %v, got %v", tt.wantCacheHit, resp.Metadata.CacheHit)
+			}
+			if resp.Metadata.ProvidersSucceeded != tt.wantSucceeded || resp.Metadata.ProvidersFailed != tt.wantFailed {
+				t.Errorf("expected %d succeeded/%d failed, got %d/%d", tt.wantSucceeded, tt.wantFailed,
+					resp.Metadata.ProvidersSucceeded, resp.Metadata.ProvidersFailed)
+			}
+		})
+	}
+}
+
+func TestService_SearchFlights_ValidationSkipsClient(t *testing.T) {
+	// No EXPECT, so any provider call fails the test
+	s := newTestService(newMockClient(t), cache.NewMemoryCache())
+
+	req := testSearchRequest
+	req.Passengers = 0
+
+	_, err := s.SearchFlights(context.Background(), req)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestService_SearchFlights_ReturnDateCacheKey(t *testing.T) {
+	oneWay := testSearchRequest
+	roundTrip := testSearchRequest
+	roundTrip.ReturnDate = "2099-12-20"
+
+	tests := []struct {
+		name         string
+		cached       SearchRequest
+		req          SearchRequest
+		wantCacheHit bool
+	}{
+		{name: "one-way after one-way hits", cached: oneWay, req: oneWay, wantCacheHit: true},
+		{name: "round trip after one-way misses", cached: oneWay, req: roundTrip},
+		{name: "round trip after round trip hits", cached: roundTrip, req: roundTrip, wantCacheHit: true},
+		{name: "one-way after round trip misses", cached: roundTrip, req: oneWay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t)
+			if !tt.wantCacheHit {
+				expectSearch(client, tt.req, FlightSearchResponse{Flights: testFlights()})
+			}
+			c := cache.NewMemoryCache()
+			s := newTestService(client, c)
+			_ = cache.SetJSON(context.Background(), c, s.generateCacheKey(tt.cached), cachedSearch{
+				Response:  FlightSearchResponse{Flights: testFlights()[:1]},
+				FetchedAt: time.Now(),
+			}, 0, cache.WithSchemaVersion(flightCacheSchemaVersion))
+
+			resp, err := s.SearchFlights(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Metadata.CacheHit != tt.wantCacheHit {
+				t.Errorf("expected cache hit %v, got %v", tt.wantCacheHit, resp.Metadata.CacheHit)
+			}
+		})
+	}
+}
+
+func TestSearchRequest_ValidateReturnDate(t *testing.T) {
+	tests := []struct {
+		returnDate string
+		wantCode   ErrorCode
+	}{
+		{returnDate: ""},
+		{returnDate: "2099-12-15"},
+		{returnDate: "2099-12-20"},
+		{returnDate: "2099-12-14", wantCode: ErrorCodeReturnBeforeDeparture},
+		{returnDate: "20-12-2099", wantCode: ErrorCodeInvalidDateFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.returnDate, func(t *testing.T) {
+			req := testSearchRequest
+			req.ReturnDate = tt.returnDate
+
+			err := req.Validate()
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Code() != tt.wantCode {
+				t.Errorf("expected %s, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
+
+// failingGetCache fails every lookup, writes still go to the wrapped cache
+type failingGetCache struct {
+	cache.Cache
+}
+
+func (failingGetCache) Get(ctx context.Context, key string) (string, error) {
+	return "", errors.New("redis: connection refused")
+}
+
+func TestService_SearchFlights_LogsCarryRouteAndTrace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	client := newMockClient(t)
+	expectSearch(client, testSearchRequest, FlightSearchResponse{Flights: testFlights()})
+	s := NewService(client, failingGetCache{cache.NewMemoryCache()}, 60, logger.NewWithWriter("development", buf))
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	if _, err := s.SearchFlights(ctx, testSearchRequest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "cache_get_err") {
+		t.Fatalf("expected the cache error to be logged, got: %s", output)
+	}
+	for _, want := range []string{`"origin":"CGK"`, `"destination":"DPS"`, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`, `"span_id":"00f067aa0ba902b7"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s on the service log without passing it, got: %s", want, output)
+		}
+	}
+}
+
+func TestService_FilterFlights(t *testing.T) {
+	maxStops := uint32(0)
+
+	tests := []struct {
+		name    string
+		filters *FilterOptions
+		sort    *SortOptions
+		wantIDs []string
+	}{
+		{
+			name:    "no filters keeps provider order",
+			wantIDs: []string{"GA400", "QZ520", "JT740"},
+		},
+		{
+			name:    "direct only",
+			filters: &FilterOptions{MaxStops: &maxStops},
+			wantIDs: []string{"GA400", "JT740"},
+		},
+		{
+			name:    "airline filter and price sort",
+			filters: &FilterOptions{Airlines: []string{"airasia", "lion air"}},
+			sort:    &SortOptions{By: "price", Order: "asc"},
+			wantIDs: []string{"QZ520", "JT740"},
+		},
+		{
+			name:    "price range sorted by duration desc",
+			filters: &FilterOptions{PriceRange: &PriceRange{Low: 900000, High: 1300000}},
+			sort:    &SortOptions{By: "duration", Order: "desc"},
+			wantIDs: []string{"GA400", "JT740"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t)
+			expectSearch(client, testSearchRequest, FlightSearchResponse{
+				Flights:  testFlights(),
+				Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+			})
+			s := newTestService(client, cache.NewMemoryCache())
+
+			resp, err := s.FilterFlights(context.Background(), FilterRequest{
+				SearchRequest: testSearchRequest,
+				Filters:       tt.filters,
+				Sort:          tt.sort,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := flightIDs(resp.Flights)
+			if !equalIDs(got, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, got)
+			}
+			if resp.Metadata.TotalResults != uint32(len(tt.wantIDs)) {
+				t.Errorf("expected total_results %d, got %d", len(tt.wantIDs), resp.Metadata.TotalResults)
+			}
+		})
+	}
+}
+
+func TestService_ApplySorting(t *testing.T) {
+	s := newTestService(newMockClient(t), cache.NewMemoryCache())
+
+	tests := []struct {
+		sort    SortOptions
+		wantIDs []string
+	}{
+		{sort: SortOptions{By: "price", Order: "asc"}, wantIDs: []string{"QZ520", "JT740", "GA400"}},
+		{sort: SortOptions{By: "price", Order: "desc"}, wantIDs: []string{"GA400", "JT740", "QZ520"}},
+		{sort: SortOptions{By: "duration", Order: "asc"}, wantIDs: []string{"JT740", "GA400", "QZ520"}},
+		{sort: SortOptions{By: "best_value", Order: "desc"}, wantIDs: []string{"JT740", "GA400", "QZ520"}},
+		{sort: SortOptions{By: "unknown"}, wantIDs: []string{"GA400", "QZ520", "JT740"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort.By+"_"+tt.sort.Order, func(t *testing.T) {
+			input := testFlights()
+			got := flightIDs(s.applySorting(input, tt.sort))
+			if !equalIDs(got, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, got)
+			}
+			if !equalIDs(flightIDs(input), []string{"GA400", "QZ520", "JT740"}) {
+				t.Errorf("expected input slice to stay untouched")
+			}
+		})
+	}
+}
+
+func TestService_WeightedBestValue(t *testing.T) {
+	weighted := SortOptions{By: "weighted_best_value", Order: "desc"}
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		wantIDs []string
+	}{
+		// every provider at the default 1.0 keeps the best_value order
+		{name: "default weights", wantIDs: []string{"JT740", "GA400", "QZ520"}},
+		{name: "garuda preferred", weights: map[string]float64{"Garuda Indonesia": 2.0}, wantIDs: []string{"GA400", "JT740", "QZ520"}},
+		{name: "airasia preferred", weights: map[string]float64{"AirAsia": 2.0}, wantIDs: []string{"JT740", "QZ520", "GA400"}},
+		{name: "lion air demoted", weights: map[string]float64{"Lion Air": 0}, wantIDs: []string{"GA400", "QZ520", "JT740"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewService(newMockClient(t), cache.NewMemoryCache(), 60,
+				logger.NewWithWriter("development", io.Discard), WithProviderWeights(tt.weights))
+
+			got := flightIDs(s.applySorting(testFlights(), weighted))
+			if !equalIDs(got, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, got)
+			}
+		})
+	}
+}
+
+func TestService_BestValueIgnoresWeights(t *testing.T) {
+	s := NewService(newMockClient(t), cache.NewMemoryCache(), 60,
+		logger.NewWithWriter("development", io.Discard), WithProviderWeights(map[string]float64{"AirAsia": 5}))
+
+	got := flightIDs(s.applySorting(testFlights(), SortOptions{By: "best_value", Order: "desc"}))
+	if want := []string{"JT740", "GA400", "QZ520"}; !equalIDs(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,151 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"travel/pkg/cache"
+)
+
+// gatedFlightClient blocks every provider call until release is closed
+type gatedFlightClient struct {
+	*MockFlightClient
+	release chan struct{}
+}
+
+func (g *gatedFlightClient) SearchFlights(ctx context.Context, req SearchRequest) (*FlightSearchResponse, error) {
+	<-g.release
+	return g.MockFlightClient.SearchFlights(ctx, req)
+}
+
+// newGatedClient expects exactly one provider call, so searches that should share a fetch
+// fail the test if they each reach the providers
+func newGatedClient(t *testing.T, release chan struct{}, resp FlightSearchResponse) *gatedFlightClient {
+	client := newMockClient(t)
+	expectSearch(client, testSearchRequest, resp)
+	return &gatedFlightClient{MockFlightClient: client, release: release}
+}
+
+// countingCache counts cache lookups so the test knows when every search has missed
+type countingCache struct {
+	cache.Cache
+	gets atomic.Int32
+}
+
+func (c *countingCache) Get(ctx context.Context, key string) (string, error) {
+	c.gets.Add(1)
+	return c.Cache.Get(ctx, key)
+}
+
+func TestService_ConcurrentMissesFetchOnce(t *testing.T) {
+	const searches = 20
+
+	client := newGatedClient(t, make(chan struct{}), FlightSearchResponse{
+		Flights:  testFlights(),
+		Metadata: Metadata{ProvidersQueried: 4, ProvidersSucceeded: 4},
+	})
+	c := &countingCache{Cache: cache.NewMemoryCache()}
+	s := newTestService(client, c)
+
+	var wg sync.WaitGroup
+	results := make([]*FlightSearchResponse, searches)
+	errs := make([]error, searches)
+	for i := range searches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = s.SearchFlights(context.Background(), testSearchRequest)
+		}()
+	}
+
+	// Let every search miss the cache and queue up behind the first provider call
+	deadline := time.Now().Add(2 * time.Second)
+	for c.gets.Load() < searches && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(client.release)
+	wg.Wait()
+
+	for i := range searches {
+		if errs[i] != nil {
+			t.Fatalf("search %d: unexpected error: %v", i, errs[i])
+		}
+		if len(results[i].Flights) != 3 {
+			t.Errorf("search %d: expected 3 flights, got %d", i, len(results[i].Flights))
+		}
+		if results[i].Metadata.CacheHit {
+			t.Errorf("search %d: expected a shared fetch, not a cache hit", i)
+		}
+	}
+}
+
+func TestService_FetchGroupIsPerService(t *testing.T) {
+	release := make(chan struct{})
+	// Each client expects its own call, a shared fetch would leave one of them unused
+	clientA := newGatedClient(t, release, FlightSearchResponse{Flights: testFlights()})
+	clientB := newGatedClient(t, release, FlightSearchResponse{Flights: testFlights()})
+	a := newTestService(clientA, cache.NewMemoryCache())
+	b := newTestService(clientB, cache.NewMemoryCache())
+
+	var wg sync.WaitGroup
+	for _, s := range []*Service{a, b} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.SearchFlights(context.Background(), testSearchRequest); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestService_SharedFetchSurvivesLeaderCancel(t *testing.T) {
+	client := newGatedClient(t, make(chan struct{}), FlightSearchResponse{Flights: testFlights()})
+	c := &countingCache{Cache: cache.NewMemoryCache()}
+	s := newTestService(client, c)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := s.SearchFlights(leaderCtx, testSearchRequest)
+		leaderErr <- err
+	}()
+	for c.gets.Load() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var followerErr error
+	var follower *FlightSearchResponse
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		follower, followerErr = s.SearchFlights(context.Background(), testSearchRequest)
+	}()
+	for c.gets.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// The leader's client goes away while the providers are still answering
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the leader to stop waiting with context.Canceled, got %v", err)
+	}
+	close(client.release)
+	<-done
+
+	if followerErr != nil {
+		t.Fatalf("expected the follower to get the shared result, got %v", followerErr)
+	}
+	if len(follower.Flights) != 3 {
+		t.Errorf("expected 3 flights, got %d", len(follower.Flights))
+	}
+}
@@ -1,47 +1,9 @@
 package flight
 
 import (
-	"fmt"
 	"time"
 )
 
-type ErrorCode string
-
-const (
-	ErrorCodeTimeout         ErrorCode = "TIMEOUT"
-	ErrorCodeInternalFailure ErrorCode = "INTERNAL_FAILURE"
-
-	ErrorCodeValidation            ErrorCode = "VALIDATION_ERROR"
-	ErrorCodeInvalidDateFormat     ErrorCode = "INVALID_DATE_FORMAT"
-	ErrorCodeDeparturePast         ErrorCode = "DEPARTURE_IN_PAST"
-	ErrorCodeReturnBeforeDeparture ErrorCode = "RETURN_BEFORE_DEPARTURE"
-	ErrorCodeInvalidPassengerCount ErrorCode = "INVALID_PASSENGER_COUNT"
-	ErrorCodeSameOriginDestination ErrorCode = "SAME_ORIGIN_DESTINATION"
-
-	ErrorCodeProviderFailed ErrorCode = "PROVIDER_FAILURE"
-)
-
-// Custom error struct that holds the code and the message
-type AppError struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
-	Status  int       `json:"-"` // HTTP Status code (not serialized to JSON)
-}
-
-// Error implements the standard error interface
-func (e *AppError) Error() string {
-	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
-}
-
-// NewError is a helper to create AppErrors easily
-func NewError(code ErrorCode, message string, status int) *AppError {
-	return &AppError{
-		Code:    code,
-		Message: message,
-		Status:  status,
-	}
-}
-
 type PriceRange struct {
 	Low  uint64 `json:"low"`
 	High uint64 `json:"high"`
@@ -82,10 +44,22 @@ type Metadata struct {
 	ProvidersQueried   uint32          `json:"providers_queried"`
 	ProvidersSucceeded uint32          `json:"providers_succeeded"`
 	ProvidersFailed    uint32          `json:"providers_failed"`
+	ProvidersSkipped   uint32          `json:"providers_skipped"`
 	ProviderErrors     []ProviderError `json:"provider_errors,omitempty"`
+	SkippedFlights     uint32          `json:"skipped_flights"`
 	SearchTimeMs       uint32          `json:"search_time_ms,omitempty"`
 	CacheHit           bool            `json:"cache_hit"`
 	CacheKey           string          `json:"cache_key,omitempty"`
+	DataAgeSeconds     uint32          `json:"data_age_seconds"` // how long ago the providers were asked, 0 on a fresh fetch
+	// Page describes the returned window of a filter response, nil on plain searches
+	Page *PageMetadata `json:"page,omitempty"`
+}
+
+type PageMetadata struct {
+	TotalResults uint32 `json:"total_results"`
+	Limit        uint32 `json:"limit"`
+	Offset       uint32 `json:"offset"`
+	HasNextPage  bool   `json:"has_next_page"`
 }
 
 type Flight struct {
@@ -104,6 +78,17 @@ type Flight struct {
 	Amenities      []string     `json:"amenities"`
 	Baggage        Baggage      `json:"baggage"`
 	BestValueScore *float64     `json:"best_value_score,omitempty"`
+	EstimatedTotal *Price       `json:"estimated_total,omitempty"`
+	// OfferToken is the provider's opaque booking reference for this fare, passed through as is.
+	// Empty when the provider doesn't return one.
+	OfferToken string `json:"offer_token,omitempty"`
+	// MaxSeatsTogether is the largest block of adjacent free seats, nil when the provider doesn't say
+	MaxSeatsTogether *uint32 `json:"max_seats_together,omitempty"`
+	// SeatsTogetherAvailable is set by the seats_together filter when the provider confirmed adjacency
+	SeatsTogetherAvailable *bool `json:"seats_together_available,omitempty"`
+	// AlternativeFlightID is set when this flight has fewer seats than passengers,
+	// pointing at the cheapest flight in the result that can seat everyone
+	AlternativeFlightID string `json:"alternative_flight_id,omitempty"`
 }
 
 type Airline struct {
@@ -140,10 +125,13 @@ type FilterOptions struct {
 	ArrivalTime   *ArrivalTime   `json:"arrival_time,omitempty"`
 	Airlines      []string       `json:"airlines,omitempty"`
 	MaxDuration   *uint32        `json:"max_duration,omitempty"`
+	// SeatsTogether keeps only flights that can seat a group of this size next to each other.
+	// Searches use it through /v1/flights/filter, whose body is the search request plus filters.
+	SeatsTogether *uint32 `json:"seats_together,omitempty"`
 }
 
 type SortOptions struct {
-	By    string `json:"by"`    // price, duration, departure_time, arrival_time, best_value
+	By    string `json:"by"`    // price, duration, departure_time, arrival_time, best_value, weighted_best_value
 	Order string `json:"order"` // asc, desc
 }
 
@@ -151,4 +139,32 @@ type FilterRequest struct {
 	SearchRequest
 	Filters *FilterOptions `json:"filters,omitempty"`
 	Sort    *SortOptions   `json:"sort,omitempty"`
+	Page    PageRequest    `json:"page"`
+}
+
+type PageRequest struct {
+	Limit  uint32 `json:"limit"`  // 1-100, defaults to 20
+	Offset uint32 `json:"offset"` // number of flights to skip
+}
+
+const (
+	ProviderStatusHealthy   = "healthy"
+	ProviderStatusUnhealthy = "unhealthy"
+)
+
+// ProviderHealth is the result of probing one airline provider
+type ProviderHealth struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"` // healthy, unhealthy
+	LatencyMs uint32 `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+type MultiCityRequest struct {
+	Legs []SearchRequest `json:"legs"`
+}
+
+type MultiCityResponse struct {
+	Legs          []FlightSearchResponse `json:"legs"`
+	TotalMinPrice Price                  `json:"total_min_price"`
 }
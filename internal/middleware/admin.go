@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSecretHeader carries the shared secret for admin endpoints
+const AdminSecretHeader = "X-Admin-Secret"
+
+// RequireAdminSecret rejects requests whose X-Admin-Secret doesn't match secret.
+// An empty secret rejects everything, so a missing config never opens the endpoints.
+func RequireAdminSecret(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		given := c.GetHeader(AdminSecretHeader)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "missing or invalid " + AdminSecretHeader,
+				"code":  "UNAUTHORIZED",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newBodyLimitRouter(limit int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(MaxBodySize(limit))
+	r.POST("/v1/flights/search", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"read": len(body)})
+	})
+	return r
+}
+
+// chunkedBody hides the length so the middleware can't rely on Content-Length
+type chunkedBody struct{ io.Reader }
+
+func TestMaxBodySize(t *testing.T) {
+	const limit = 1024
+
+	tests := []struct {
+		name       string
+		size       int
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "just under the limit", size: limit - 1, wantStatus: http.StatusOK},
+		{name: "exactly the limit", size: limit, wantStatus: http.StatusOK},
+		{name: "just over the limit", size: limit + 1, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "over the limit without content length", size: limit + 1, chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "under the limit without content length", size: limit - 1, chunked: true, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader = strings.NewReader(strings.Repeat("a", tt.size))
+			if tt.chunked {
+				body = chunkedBody{body}
+			}
+			req := httptest.NewRequest(http.MethodPost, "/v1/flights/search", body)
+			w := httptest.NewRecorder()
+			newBodyLimitRouter(limit).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusOK && !strings.Contains(w.Body.String(), fmt.Sprintf(`"read":%d`, tt.size)) {
+				t.Errorf("expected handler to read the full body, got %s", w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Compress gzips (or deflates) responses of at least minSize bytes when the client accepts it.
+// Responses that are already encoded, already compressed formats and streamed responses
+// (text/event-stream, or any handler that flushes) are passed through untouched.
+func Compress(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		defer func() { c.Writer = w.ResponseWriter }()
+
+		c.Next()
+
+		if w.passthrough {
+			return
+		}
+		body := w.buf.Bytes()
+		if len(body) < minSize || !compressible(w.Header()) {
+			w.writeRaw(body)
+			return
+		}
+
+		var compressed bytes.Buffer
+		if err := compressBody(&compressed, encoding, body); err != nil {
+			w.writeRaw(body)
+			return
+		}
+		h := w.Header()
+		h.Set("Content-Encoding", encoding)
+		h.Add("Vary", "Accept-Encoding")
+		h.Del("Content-Length")
+		w.writeRaw(compressed.Bytes())
+	}
+}
+
+// negotiateEncoding picks gzip, then deflate, from an Accept-Encoding header. Empty means identity.
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		if accepted[encoding] || (accepted["*"] && !hasExplicit(header, encoding)) {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// hasExplicit reports whether the header names the encoding itself, so "*" doesn't override "gzip;q=0"
+func hasExplicit(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(name), encoding) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressible is false for bodies that are already encoded, already compressed or streamed
+func compressible(h http.Header) bool {
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(h.Get("Content-Type"))
+	for _, prefix := range []string{"image/", "video/", "audio/", "text/event-stream",
+		"application/zip", "application/gzip", "application/x-gzip", "application/octet-stream"} {
+		if strings.HasPrefix(contentType, prefix) {
+			return strings.HasPrefix(contentType, "image/svg")
+		}
+	}
+	return true
+}
+
+func compressBody(dst io.Writer, encoding string, body []byte) error {
+	var zw io.WriteCloser
+	if encoding == "gzip" {
+		zw = gzip.NewWriter(dst)
+	} else {
+		// HTTP "deflate" is the zlib format (RFC 1950), not a raw deflate stream
+		zw = zlib.NewWriter(dst)
+	}
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// bufferedWriter holds the body until the handler is done, so it can be measured, hashed or compressed.
+// A Flush from the handler switches it to passthrough, streaming is never buffered.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	passthrough bool
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *bufferedWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		w.writeRaw(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *bufferedWriter) writeRaw(b []byte) {
+	if len(b) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(b)
+}
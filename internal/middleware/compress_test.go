@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCompressRouter(minSize int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress(minSize))
+	r.GET("/large", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"flights": strings.Repeat("GA400 CGK-DPS ", 200)})
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.GET("/png", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", bytes.Repeat([]byte{0x89}, 4096))
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		for i := 0; i < 3; i++ {
+			_, _ = c.Writer.WriteString(strings.Repeat("chunk ", 200))
+			c.Writer.Flush()
+		}
+	})
+	return r
+}
+
+func doRequest(r http.Handler, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompress_LargeBodyIsGzipped(t *testing.T) {
+	w := doRequest(newCompressRouter(1024), "/large", "gzip, deflate")
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected gzip encoding, got %q", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Errorf("expected Vary: Accept-Encoding, got %q", got)
+	}
+	zr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("invalid gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if !strings.Contains(string(body), "GA400 CGK-DPS") {
+		t.Errorf("unexpected decompressed body: %.80s", body)
+	}
+}
+
+func TestCompress_DeflateWhenGzipNotAccepted(t *testing.T) {
+	w := doRequest(newCompressRouter(1024), "/large", "gzip;q=0, deflate")
+
+	if got := w.Header().Get("Content-Encoding"); got != "deflate" {
+		t.Fatalf("expected deflate encoding, got %q", got)
+	}
+	zr, err := zlib.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("invalid deflate body: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if !strings.Contains(string(body), "GA400 CGK-DPS") {
+		t.Errorf("unexpected decompressed body: %.80s", body)
+	}
+}
+
+func TestCompress_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantBody       string
+	}{
+		{name: "small body", path: "/small", acceptEncoding: "gzip", wantBody: `{"status":"ok"}`},
+		{name: "client without gzip", path: "/large", acceptEncoding: "", wantBody: "GA400 CGK-DPS"},
+		{name: "identity only", path: "/large", acceptEncoding: "identity", wantBody: "GA400 CGK-DPS"},
+		{name: "already compressed format", path: "/png", acceptEncoding: "gzip"},
+		{name: "streaming response", path: "/stream", acceptEncoding: "gzip", wantBody: "chunk chunk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(newCompressRouter(1024), tt.path, tt.acceptEncoding)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", w.Code)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("expected no content encoding, got %q", got)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %q, got %.80s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "gzip", want: "gzip"},
+		{header: "deflate, gzip", want: "gzip"},
+		{header: "GZIP;q=0.5", want: "gzip"},
+		{header: "br, deflate", want: "deflate"},
+		{header: "gzip;q=0", want: ""},
+		{header: "*", want: "gzip"},
+		{header: "*, gzip;q=0", want: "deflate"},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
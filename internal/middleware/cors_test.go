@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCORSRouter(cfg CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORS(cfg))
+	r.POST("/v1/flights/search", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"flights": []string{}}) })
+	return r
+}
+
+var testCORSConfig = CORSConfig{
+	AllowedOrigins:   []string{"https://app.example.com"},
+	AllowedMethods:   []string{"GET", "POST"},
+	AllowedHeaders:   []string{"Content-Type", "Authorization"},
+	MaxAge:           600,
+	AllowCredentials: true,
+}
+
+func preflight(r http.Handler, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/v1/flights/search", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORS_PreflightAllowedOrigin(t *testing.T) {
+	w := preflight(newCORSRouter(testCORSConfig), "https://app.example.com")
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	want := map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.example.com",
+		"Access-Control-Allow-Methods":     "GET, POST",
+		"Access-Control-Allow-Headers":     "Content-Type, Authorization",
+		"Access-Control-Max-Age":           "600",
+		"Access-Control-Allow-Credentials": "true",
+		"Vary":                             "Origin",
+	}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("%s: expected %q, got %q", header, value, got)
+		}
+	}
+}
+
+func TestCORS_PreflightDisallowedOrigin(t *testing.T) {
+	w := preflight(newCORSRouter(testCORSConfig), "https://evil.example.com")
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+	for _, header := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods", "Access-Control-Allow-Credentials"} {
+		if got := w.Header().Get(header); got != "" {
+			t.Errorf("expected no %s for disallowed origin, got %q", header, got)
+		}
+	}
+}
+
+func TestCORS_SimpleRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        CORSConfig
+		origin     string
+		wantOrigin string
+	}{
+		{name: "allowed origin", cfg: testCORSConfig, origin: "https://app.example.com", wantOrigin: "https://app.example.com"},
+		{name: "disallowed origin", cfg: testCORSConfig, origin: "https://evil.example.com", wantOrigin: ""},
+		{name: "no origin", cfg: testCORSConfig, origin: "", wantOrigin: ""},
+		{name: "wildcard", cfg: CORSConfig{AllowedOrigins: []string{"*"}}, origin: "https://any.example.com", wantOrigin: "*"},
+		{name: "wildcard with credentials never echoes origin", cfg: CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			origin: "https://any.example.com", wantOrigin: "*"},
+		{name: "listed origin next to wildcard", cfg: CORSConfig{AllowedOrigins: []string{"*", "https://app.example.com"}, AllowCredentials: true},
+			origin: "https://app.example.com", wantOrigin: "https://app.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/flights/search", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			newCORSRouter(tt.cfg).ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected request to be served, got %d", w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.wantOrigin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); tt.wantOrigin == "*" && got != "" {
+				t.Errorf("expected no credentials for a wildcard origin, got %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newETagRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/flights/filter", ETagger(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"flights": []string{c.Query("flight")}})
+	})
+	r.POST("/v1/flights/invalid", ETagger(), func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid"})
+	})
+	return r
+}
+
+func postWithETag(r http.Handler, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestETagger_SameBodySameETag(t *testing.T) {
+	r := newETagRouter()
+	first := postWithETag(r, "/v1/flights/filter?flight=GA400", "")
+	second := postWithETag(r, "/v1/flights/filter?flight=GA400", "")
+
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("expected ETag header")
+	}
+	if got := second.Header().Get("ETag"); got != etag {
+		t.Errorf("expected identical responses to share an ETag, got %q and %q", etag, got)
+	}
+}
+
+func TestETagger_DifferentBodyDifferentETag(t *testing.T) {
+	r := newETagRouter()
+	a := postWithETag(r, "/v1/flights/filter?flight=GA400", "").Header().Get("ETag")
+	b := postWithETag(r, "/v1/flights/filter?flight=QZ520", "").Header().Get("ETag")
+
+	if a == b {
+		t.Errorf("expected different responses to have different ETags, both got %q", a)
+	}
+}
+
+func TestETagger_IfNoneMatch(t *testing.T) {
+	r := newETagRouter()
+	etag := postWithETag(r, "/v1/flights/filter?flight=GA400", "").Header().Get("ETag")
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "matching tag", ifNoneMatch: etag, wantStatus: http.StatusNotModified},
+		{name: "strong form of the tag", ifNoneMatch: etag[2:], wantStatus: http.StatusNotModified},
+		{name: "tag in a list", ifNoneMatch: `"stale", ` + etag, wantStatus: http.StatusNotModified},
+		{name: "other tag", ifNoneMatch: `W/"stale"`, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postWithETag(r, "/v1/flights/filter?flight=GA400", tt.ifNoneMatch)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusNotModified && w.Body.Len() != 0 {
+				t.Errorf("expected empty body on 304, got %q", w.Body.String())
+			}
+			if w.Header().Get("ETag") != etag {
+				t.Errorf("expected ETag %q on response, got %q", etag, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestETagger_SkipsErrors(t *testing.T) {
+	w := postWithETag(newETagRouter(), "/v1/flights/invalid", "")
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if got := w.Header().Get("ETag"); got != "" {
+		t.Errorf("expected no ETag on error responses, got %q", got)
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"time"
+	"travel/pkg/synthetic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LatencyRecorder receives the duration of every routed request
+type LatencyRecorder interface {
+	Record(endpoint string, latency time.Duration)
+}
+
+// TrackLatency records each request under "METHOD /route/pattern".
+// Unmatched routes are skipped so random 404 paths can't grow the recorder,
+// and synthetic monitoring requests are skipped so they don't count towards the SLO.
+func TrackLatency(recorder LatencyRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" || synthetic.FromContext(c.Request.Context()) {
+			return
+		}
+		recorder.Record(c.Request.Method+" "+route, time.Since(start))
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type recordedLatency struct {
+	endpoints []string
+}
+
+func (r *recordedLatency) Record(endpoint string, latency time.Duration) {
+	r.endpoints = append(r.endpoints, endpoint)
+}
+
+func TestTrackLatency_RecordsRoutePattern(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := &recordedLatency{}
+	r := gin.New()
+	r.Use(TrackLatency(rec))
+	r.GET("/v1/flights/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/v1/flights/GA400", "/v1/flights/QZ520", "/unknown"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if len(rec.endpoints) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %v", rec.endpoints)
+	}
+	for _, e := range rec.endpoints {
+		if e != "GET /v1/flights/:id" {
+			t.Errorf("expected route pattern, got %q", e)
+		}
+	}
+}
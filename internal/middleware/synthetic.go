@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"strconv"
+	"travel/pkg/synthetic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagSynthetic marks requests carrying X-Synthetic: true in the request context.
+// Values that don't parse as a bool are treated as real traffic.
+func TagSynthetic() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tagged, _ := strconv.ParseBool(c.GetHeader(synthetic.Header)); tagged {
+			c.Request = c.Request.WithContext(synthetic.WithTag(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
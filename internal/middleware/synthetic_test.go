@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"travel/internal/slo"
+	"travel/pkg/synthetic"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTagSynthetic(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "true", header: "true", want: true},
+		{name: "numeric true", header: "1", want: true},
+		{name: "false", header: "false"},
+		{name: "missing"},
+		{name: "garbage", header: "yes-please"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(TagSynthetic())
+			var got bool
+			r.POST("/v1/flights/search", func(c *gin.Context) {
+				got = synthetic.FromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/flights/search", nil)
+			if tt.header != "" {
+				req.Header.Set(synthetic.Header, tt.header)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("expected synthetic=%v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTagSynthetic_ExcludedFromSLO(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tracker := slo.NewTracker(time.Second, time.Minute, 100)
+	r := gin.New()
+	r.Use(TagSynthetic(), TrackLatency(tracker))
+	r.POST("/v1/flights/search", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, header := range []string{"true", "true", "", "false", "true"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/flights/search", nil)
+		if header != "" {
+			req.Header.Set(synthetic.Header, header)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	report := tracker.Snapshot()["POST /v1/flights/search"]
+	if report.Count != 2 {
+		t.Errorf("expected only the 2 real requests in the SLO, got %d", report.Count)
+	}
+}
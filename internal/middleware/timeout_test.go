@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTimeoutRouter(d time.Duration, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.GET("/v1/slow", Timeout(d), handler)
+	return r
+}
+
+func TestTimeout_SlowHandler(t *testing.T) {
+	var afterTimeout bool
+	r := newTimeoutRouter(20*time.Millisecond, func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusOK, gin.H{"late": true})
+		afterTimeout = true
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/slow", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"TIMEOUT"`) {
+		t.Errorf("expected TIMEOUT error body, got %q", w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "late") {
+		t.Errorf("late handler output leaked into the response: %q", w.Body.String())
+	}
+	if !afterTimeout {
+		t.Error("expected middleware to wait for the handler before returning")
+	}
+}
+
+func TestTimeout_AbortsRemainingHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	var outerSawAbort bool
+	r.GET("/v1/slow",
+		func(c *gin.Context) {
+			c.Next()
+			outerSawAbort = c.IsAborted()
+		},
+		Timeout(10*time.Millisecond),
+		func(c *gin.Context) { <-c.Request.Context().Done() },
+	)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/slow", nil))
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", w.Code)
+	}
+	if !outerSawAbort {
+		t.Error("expected the context to be aborted after a timeout")
+	}
+}
+
+func TestTimeout_FastHandler(t *testing.T) {
+	r := newTimeoutRouter(time.Second, func(c *gin.Context) {
+		if _, ok := c.Request.Context().Deadline(); !ok {
+			t.Error("expected request context to carry a deadline")
+		}
+		c.Header("X-Provider", "garuda")
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/slow", nil))
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if got := w.Header().Get("X-Provider"); got != "garuda" {
+		t.Errorf("expected handler header to be kept, got %q", got)
+	}
+	if got := w.Body.String(); got != `{"ok":true}` {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestTimeout_PanicReachesRecovery(t *testing.T) {
+	r := newTimeoutRouter(time.Second, func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/slow", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 from recovery, got %d", w.Code)
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContext(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+
+	tests := []struct {
+		name        string
+		traceparent string
+		wantTraceID string
+	}{
+		{
+			name:        "caller trace is continued",
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{name: "no traceparent"},
+		{name: "malformed traceparent", traceparent: "not-a-trace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(TraceContext())
+			var got trace.SpanContext
+			r.GET("/v1/currencies", func(c *gin.Context) {
+				got = trace.SpanContextFromContext(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/currencies", nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tt.wantTraceID == "" {
+				if got.IsValid() {
+					t.Errorf("expected no trace, got %s", got.TraceID())
+				}
+				return
+			}
+			if got.TraceID().String() != tt.wantTraceID {
+				t.Errorf("expected trace %s, got %s", tt.wantTraceID, got.TraceID())
+			}
+		})
+	}
+}
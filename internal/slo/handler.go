@@ -0,0 +1,42 @@
+package slo
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SLOHandler struct {
+	tracker *Tracker
+}
+
+func NewSLOHandler(tracker *Tracker) *SLOHandler {
+	return &SLOHandler{
+		tracker: tracker,
+	}
+}
+
+func (h *SLOHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/slo", h.GetSLOHandler)
+}
+
+type SLOResponse struct {
+	TargetMs      float64           `json:"target_ms"`
+	WindowSeconds float64           `json:"window_seconds"`
+	Endpoints     map[string]Report `json:"endpoints"`
+}
+
+// GetSLOHandler godoc
+// @Summary      Latency SLO report
+// @Description  P50/P95/P99 and the share of requests within the latency target, per endpoint, over the rolling window
+// @Tags         observability
+// @Produce      json
+// @Success      200 {object} SLOResponse
+// @Router       /slo [get]
+func (h *SLOHandler) GetSLOHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, SLOResponse{
+		TargetMs:      toMs(h.tracker.Target()),
+		WindowSeconds: h.tracker.Window().Seconds(),
+		Endpoints:     h.tracker.Snapshot(),
+	})
+}
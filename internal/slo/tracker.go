@@ -0,0 +1,134 @@
+package slo
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Report is the latency summary of one endpoint over the rolling window
+type Report struct {
+	Count            int     `json:"count"`
+	P50Ms            float64 `json:"p50_ms"`
+	P95Ms            float64 `json:"p95_ms"`
+	P99Ms            float64 `json:"p99_ms"`
+	WithinSLOPercent float64 `json:"within_slo_percent"`
+}
+
+type sample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// ring keeps the last len(samples) latencies of an endpoint, oldest are overwritten first
+type ring struct {
+	samples []sample
+	next    int
+	full    bool
+}
+
+func (r *ring) add(s sample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Tracker records request latencies per endpoint and reports percentiles over a rolling window.
+// Memory is bounded by maxSamples per endpoint, under heavy traffic the window is effectively
+// the last maxSamples requests.
+type Tracker struct {
+	target     time.Duration
+	window     time.Duration
+	maxSamples int
+	now        func() time.Time
+
+	mu        sync.Mutex
+	endpoints map[string]*ring
+}
+
+func NewTracker(target, window time.Duration, maxSamples int) *Tracker {
+	if maxSamples < 1 {
+		maxSamples = 1
+	}
+	return &Tracker{
+		target:     target,
+		window:     window,
+		maxSamples: maxSamples,
+		now:        time.Now,
+		endpoints:  make(map[string]*ring),
+	}
+}
+
+func (t *Tracker) Target() time.Duration { return t.target }
+func (t *Tracker) Window() time.Duration { return t.window }
+
+func (t *Tracker) Record(endpoint string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r, ok := t.endpoints[endpoint]
+	if !ok {
+		r = &ring{samples: make([]sample, t.maxSamples)}
+		t.endpoints[endpoint] = r
+	}
+	r.add(sample{at: t.now(), latency: latency})
+}
+
+// Snapshot returns a report per endpoint, endpoints without requests in the window are left out
+func (t *Tracker) Snapshot() map[string]Report {
+	t.mu.Lock()
+	cutoff := t.now().Add(-t.window)
+	latencies := make(map[string][]time.Duration, len(t.endpoints))
+	for endpoint, r := range t.endpoints {
+		n := r.next
+		if r.full {
+			n = len(r.samples)
+		}
+		for _, s := range r.samples[:n] {
+			if s.at.After(cutoff) {
+				latencies[endpoint] = append(latencies[endpoint], s.latency)
+			}
+		}
+	}
+	t.mu.Unlock()
+
+	reports := make(map[string]Report, len(latencies))
+	for endpoint, values := range latencies {
+		reports[endpoint] = t.report(values)
+	}
+	return reports
+}
+
+func (t *Tracker) report(values []time.Duration) Report {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	within := 0
+	for _, v := range values {
+		if v <= t.target {
+			within++
+		}
+	}
+	return Report{
+		Count:            len(values),
+		P50Ms:            toMs(percentile(values, 50)),
+		P95Ms:            toMs(percentile(values, 95)),
+		P99Ms:            toMs(percentile(values, 99)),
+		WithinSLOPercent: float64(within) * 100 / float64(len(values)),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted values
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func toMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,78 @@
+package slo
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestTracker(maxSamples int) (*Tracker, *time.Time) {
+	now := time.Date(2025, 12, 15, 6, 0, 0, 0, time.UTC)
+	tr := NewTracker(2*time.Second, 5*time.Minute, maxSamples)
+	tr.now = func() time.Time { return now }
+	return tr, &now
+}
+
+func TestTracker_Percentiles(t *testing.T) {
+	tr, _ := newTestTracker(1000)
+	// 1ms..100ms, so the nearest-rank percentiles are exact
+	for i := 1; i <= 100; i++ {
+		tr.Record("POST /v1/flights/search", time.Duration(i)*time.Millisecond)
+	}
+
+	got := tr.Snapshot()["POST /v1/flights/search"]
+	want := Report{Count: 100, P50Ms: 50, P95Ms: 95, P99Ms: 99, WithinSLOPercent: 100}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestTracker_WithinSLOPercent(t *testing.T) {
+	tr, _ := newTestTracker(1000)
+	latencies := []time.Duration{
+		500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second,
+	}
+	for _, l := range latencies {
+		tr.Record("POST /v1/flights/search", l)
+	}
+
+	got := tr.Snapshot()["POST /v1/flights/search"]
+	if got.WithinSLOPercent != 75 {
+		t.Errorf("expected 75%% within 2s target, got %v", got.WithinSLOPercent)
+	}
+	if got.P50Ms != 1000 || got.P99Ms != 3000 {
+		t.Errorf("expected p50 1000ms and p99 3000ms, got %+v", got)
+	}
+}
+
+func TestTracker_RollingWindow(t *testing.T) {
+	tr, now := newTestTracker(1000)
+	tr.Record("POST /v1/flights/search", 3*time.Second)
+	*now = now.Add(6 * time.Minute)
+	tr.Record("POST /v1/flights/search", 100*time.Millisecond)
+	tr.Record("POST /v1/flights/filter", 100*time.Millisecond)
+
+	reports := tr.Snapshot()
+	if got := reports["POST /v1/flights/search"]; got.Count != 1 || got.P99Ms != 100 {
+		t.Errorf("expected the old sample to fall out of the window, got %+v", got)
+	}
+
+	*now = now.Add(6 * time.Minute)
+	if reports := tr.Snapshot(); len(reports) != 0 {
+		t.Errorf("expected no endpoints once every sample expired, got %+v", reports)
+	}
+}
+
+func TestTracker_BoundedSamples(t *testing.T) {
+	tr, _ := newTestTracker(10)
+	for i := 0; i < 10; i++ {
+		tr.Record("GET /v1/currencies", 5*time.Second)
+	}
+	for i := 0; i < 10; i++ {
+		tr.Record("GET /v1/currencies", time.Millisecond)
+	}
+
+	got := tr.Snapshot()["GET /v1/currencies"]
+	if got.Count != 10 || got.P99Ms != 1 {
+		t.Errorf("expected only the 10 newest samples kept, got %+v", got)
+	}
+}
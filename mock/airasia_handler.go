@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"math/rand"
 	"net/http"
 	"os"
 	"strings"
@@ -87,12 +86,11 @@ func AirAsiaSearchHandler(w http.ResponseWriter, r *http.Request) {
 		filteredFlights = append(filteredFlights, f)
 	}
 
-	// Simulate random delay (50-150ms)
-	delay := 50 + rand.Intn(101) // 50 to 150ms
-	time.Sleep(time.Duration(delay) * time.Millisecond)
+	// Simulate random delay (50-150ms) unless ?delay_ms= is given
+	simulateDelay(r, 50, 150)
 
 	// 90% success rate, 10% failure
-	if rand.Float64() < 0.1 {
+	if failRandomly(r, 0.1) {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
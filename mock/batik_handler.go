@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"math/rand"
 	"net/http"
 	"os"
 	"strings"
@@ -113,9 +112,8 @@ func BatikSearchHandler(w http.ResponseWriter, r *http.Request) {
 		filtered = append(filtered, f)
 	}
 
-	// Simulate random delay (200-400ms)
-	delay := 200 + rand.Intn(201) // 200 to 400ms
-	time.Sleep(time.Duration(delay) * time.Millisecond)
+	// Simulate random delay (200-400ms) unless ?delay_ms= is given
+	simulateDelay(r, 200, 400)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(BatikResponse{Results: filtered})
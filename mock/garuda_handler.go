@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"math/rand"
 	"net/http"
 	"os"
 	"strings"
@@ -126,8 +125,8 @@ func GarudaSearchHandler(w http.ResponseWriter, r *http.Request) {
 		filtered = append(filtered, f)
 	}
 
-	delay := 50 + rand.Intn(51) // 50 to 100ms
-	time.Sleep(time.Duration(delay) * time.Millisecond)
+	// Simulate random delay (50-100ms) unless ?delay_ms= is given
+	simulateDelay(r, 50, 100)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(GarudaResponse{Status: "success", Flights: filtered})
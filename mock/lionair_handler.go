@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"math/rand"
 	"net/http"
 	"os"
 	"strings"
@@ -142,9 +141,8 @@ func LionAirSearchHandler(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	// Simulate random delay (100-200ms)
-	delay := 100 + rand.Intn(101) // 100 to 200ms
-	time.Sleep(time.Duration(delay) * time.Millisecond)
+	// Simulate random delay (100-200ms) unless ?delay_ms= is given
+	simulateDelay(r, 100, 200)
 
 	json.NewEncoder(w).Encode(response)
 }
@@ -23,11 +23,16 @@ func main() {
 		port = os.Args[1]
 	}
 	http.HandleFunc("/health", HealthCheckHandler)
+	http.HandleFunc("/airasia/health", HealthCheckHandler)
+	http.HandleFunc("/batikair/health", HealthCheckHandler)
+	http.HandleFunc("/garuda/health", HealthCheckHandler)
+	http.HandleFunc("/lionair/health", HealthCheckHandler)
 
-	http.HandleFunc("/airasia/v1/flights/search", AirAsiaSearchHandler)
-	http.HandleFunc("/batikair/v1/flights/search", BatikSearchHandler)
-	http.HandleFunc("/garuda/v1/flights/search", GarudaSearchHandler)
-	http.HandleFunc("/lionair/v1/flights/search", LionAirSearchHandler)
+	// Every provider accepts ?delay_ms=<0-30000> and ?error=timeout|500, see simulation.go
+	http.HandleFunc("/airasia/v1/flights/search", withSimulation("airasia", AirAsiaSearchHandler))
+	http.HandleFunc("/batikair/v1/flights/search", withSimulation("batikair", BatikSearchHandler))
+	http.HandleFunc("/garuda/v1/flights/search", withSimulation("garuda", GarudaSearchHandler))
+	http.HandleFunc("/lionair/v1/flights/search", withSimulation("lionair", LionAirSearchHandler))
 
 	addr := fmt.Sprintf(":%s", port)
 	fmt.Printf("Go Mock Server running on port %s...\n", port)
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxDelayMs caps ?delay_ms= so a typo can't hang a request for hours
+const maxDelayMs = 30000
+
+// timeoutHold is how long ?error=timeout keeps the connection open when the client doesn't give up first
+const timeoutHold = 60 * time.Second
+
+// simulation is the latency and failure mode asked for with ?delay_ms= and ?error=
+type simulation struct {
+	delay    time.Duration
+	hasDelay bool
+	failure  string // "", "timeout" or "500"
+}
+
+func (s simulation) String() string {
+	delay, failure := "random", "none"
+	if s.hasDelay {
+		delay = s.delay.String()
+	}
+	if s.failure != "" {
+		failure = s.failure
+	}
+	return fmt.Sprintf("delay=%s error=%s", delay, failure)
+}
+
+func parseSimulation(r *http.Request) (simulation, error) {
+	var sim simulation
+	q := r.URL.Query()
+
+	if raw := q.Get("delay_ms"); raw != "" {
+		ms, err := strconv.Atoi(raw)
+		if err != nil || ms < 0 {
+			return sim, fmt.Errorf("delay_ms must be a non-negative number of milliseconds, got %q", raw)
+		}
+		if ms > maxDelayMs {
+			return sim, fmt.Errorf("delay_ms must be at most %d, got %d", maxDelayMs, ms)
+		}
+		sim.delay = time.Duration(ms) * time.Millisecond
+		sim.hasDelay = true
+	}
+
+	switch failure := q.Get("error"); failure {
+	case "", "timeout", "500":
+		sim.failure = failure
+	default:
+		return sim, fmt.Errorf("error must be timeout or 500, got %q", failure)
+	}
+	return sim, nil
+}
+
+type simulationKey struct{}
+
+// withSimulation applies ?delay_ms= and ?error= to a provider handler and logs the active mode
+func withSimulation(provider string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sim, err := parseSimulation(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("%s %s %s", provider, r.URL.Path, sim)
+
+		switch sim.failure {
+		case "timeout":
+			// Hold the request until the client gives up
+			select {
+			case <-r.Context().Done():
+			case <-time.After(timeoutHold):
+				http.Error(w, "Gateway Timeout", http.StatusGatewayTimeout)
+			}
+			return
+		case "500":
+			time.Sleep(sim.delay)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), simulationKey{}, sim)))
+	}
+}
+
+// simulateDelay sleeps for ?delay_ms= when given, otherwise for a random duration in [min, max]
+func simulateDelay(r *http.Request, min, max int) {
+	if sim, ok := r.Context().Value(simulationKey{}).(simulation); ok && sim.hasDelay {
+		time.Sleep(sim.delay)
+		return
+	}
+	time.Sleep(time.Duration(min+rand.Intn(max-min+1)) * time.Millisecond)
+}
+
+// failRandomly reports true for rate of the requests, never when a delay was asked for explicitly
+// so latency experiments stay deterministic
+func failRandomly(r *http.Request, rate float64) bool {
+	if sim, ok := r.Context().Value(simulationKey{}).(simulation); ok && sim.hasDelay {
+		return false
+	}
+	return rand.Float64() < rate
+}
@@ -2,12 +2,25 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss is returned when the requested key does not exist (or has expired)
+var ErrCacheMiss = errors.New("cache: key not found")
+
+// NoExpiry is returned by TTL for keys that exist but never expire
+const NoExpiry time.Duration = -1
+
 type Cache interface {
-	Set(ctx context.Context, key string, value string, ttl time.Duration) error
-	SetNX(ctx context.Context, key string, value string, ttl time.Duration) error
 	Get(ctx context.Context, key string) (string, error)
-	Del(ctx context.Context, key string) error
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	// SetNX sets the key only if it does not exist yet, reporting whether it was set.
+	SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	// MGet returns only the keys that were found, missing keys are left out of the map.
+	MGet(ctx context.Context, keys ...string) (map[string]string, error)
+	MSet(ctx context.Context, values map[string]string, ttl time.Duration) error
 }
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Values written with compression enabled carry a one byte header telling how the
+// rest is encoded. Values without a known header are entries written before
+// compression existed (plain JSON) and are returned untouched.
+const (
+	encodingRaw  byte = 0x00
+	encodingGzip byte = 0x01
+)
+
+// encodeValue gzips value when it is at least threshold bytes, otherwise stores it raw behind the header
+func encodeValue(value string, threshold int) (string, error) {
+	if len(value) < threshold {
+		return string(encodingRaw) + value, nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(encodingGzip)
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(value)); err != nil {
+		return "", fmt.Errorf("cache: gzip write failed: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("cache: gzip close failed: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func decodeValue(stored string) (string, error) {
+	if stored == "" {
+		return stored, nil
+	}
+
+	switch stored[0] {
+	case encodingRaw:
+		return stored[1:], nil
+	case encodingGzip:
+		zr, err := gzip.NewReader(bytes.NewReader([]byte(stored[1:])))
+		if err != nil {
+			return "", fmt.Errorf("cache: gzip reader failed: %w", err)
+		}
+		defer zr.Close()
+
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return "", fmt.Errorf("cache: gzip read failed: %w", err)
+		}
+		return string(data), nil
+	default:
+		// Legacy uncompressed entry
+		return stored, nil
+	}
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type jsonOptions struct {
+	schemaVersion string
+}
+
+// JSONOption configures GetJSON and SetJSON
+type JSONOption func(*jsonOptions)
+
+// WithSchemaVersion prefixes stored values with the version. Entries written with another
+// (or no) version are treated as a miss, so a deploy that changes the cached shape
+// doesn't have to flush the cache.
+func WithSchemaVersion(version string) JSONOption {
+	return func(o *jsonOptions) {
+		o.schemaVersion = version
+	}
+}
+
+func newJSONOptions(opts []JSONOption) jsonOptions {
+	var o jsonOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// versionPrefix is "<version>|", empty when no version is used
+func (o jsonOptions) versionPrefix() string {
+	if o.schemaVersion == "" {
+		return ""
+	}
+	return o.schemaVersion + "|"
+}
+
+// GetJSON reads key and decodes it into T.
+// A missing key or a schema version mismatch reports found=false with a nil error,
+// err is only set when the cache fails or the stored value can't be decoded.
+func GetJSON[T any](ctx context.Context, c Cache, key string, opts ...JSONOption) (T, bool, error) {
+	var value T
+	o := newJSONOptions(opts)
+
+	raw, err := c.Get(ctx, key)
+	if errors.Is(err, ErrCacheMiss) {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+
+	prefix := o.versionPrefix()
+	if !strings.HasPrefix(raw, prefix) {
+		return value, false, nil
+	}
+	if err := json.Unmarshal([]byte(raw[len(prefix):]), &value); err != nil {
+		return value, false, fmt.Errorf("cache: decode %s: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// SetJSON encodes value as JSON and stores it under key
+func SetJSON[T any](ctx context.Context, c Cache, key string, value T, ttl time.Duration, opts ...JSONOption) error {
+	o := newJSONOptions(opts)
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cache: encode %s: %w", key, err)
+	}
+	return c.Set(ctx, key, o.versionPrefix()+string(data), ttl)
+}
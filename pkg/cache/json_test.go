@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type cachedRoute struct {
+	Origin      string `json:"origin"`
+	Destination string `json:"destination"`
+	Flights     int    `json:"flights"`
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+	want := cachedRoute{Origin: "CGK", Destination: "DPS", Flights: 3}
+
+	if err := SetJSON(ctx, c, "route", want, time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	got, found, err := GetJSON[cachedRoute](ctx, c, "route")
+	if err != nil || !found {
+		t.Fatalf("expected hit, got found=%v err=%v", found, err)
+	}
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestGetJSON_MissIsNotAnError(t *testing.T) {
+	_, found, err := GetJSON[cachedRoute](context.Background(), NewMemoryCache(), "missing")
+	if found || err != nil {
+		t.Errorf("expected plain miss, got found=%v err=%v", found, err)
+	}
+}
+
+func TestGetJSON_TypeMismatchIsAnError(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+	_ = SetJSON(ctx, c, "route", []string{"CGK", "DPS"}, time.Minute)
+
+	_, found, err := GetJSON[cachedRoute](ctx, c, "route")
+	if found || err == nil {
+		t.Errorf("expected decode error, got found=%v err=%v", found, err)
+	}
+}
+
+func TestGetJSON_CacheErrorIsReturned(t *testing.T) {
+	_, found, err := GetJSON[cachedRoute](context.Background(), failingCache{}, "route")
+	if found || !errors.Is(err, errRemoteDown) {
+		t.Errorf("expected cache error, got found=%v err=%v", found, err)
+	}
+}
+
+func TestGetJSON_SchemaVersionMismatchIsMiss(t *testing.T) {
+	ctx := context.Background()
+	value := cachedRoute{Origin: "CGK", Destination: "DPS", Flights: 3}
+
+	tests := []struct {
+		name      string
+		write     []JSONOption
+		read      []JSONOption
+		wantFound bool
+	}{
+		{name: "same version", write: []JSONOption{WithSchemaVersion("v2")}, read: []JSONOption{WithSchemaVersion("v2")}, wantFound: true},
+		{name: "old version", write: []JSONOption{WithSchemaVersion("v1")}, read: []JSONOption{WithSchemaVersion("v2")}},
+		{name: "written before versioning", write: nil, read: []JSONOption{WithSchemaVersion("v2")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache()
+			if err := SetJSON(ctx, c, "route", value, time.Minute, tt.write...); err != nil {
+				t.Fatalf("set: %v", err)
+			}
+			got, found, err := GetJSON[cachedRoute](ctx, c, "route", tt.read...)
+			if err != nil {
+				t.Fatalf("version mismatch must not be an error, got %v", err)
+			}
+			if found != tt.wantFound {
+				t.Fatalf("expected found=%v, got %v", tt.wantFound, found)
+			}
+			if found && got != value {
+				t.Errorf("expected %+v, got %+v", value, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestMemoryCache(now *time.Time) *memoryCache {
+	c := NewMemoryCache().(*memoryCache)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestMemoryCache_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+
+	if _, err := c.Get(ctx, "missing"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected ErrCacheMiss, got %v", err)
+	}
+
+	if err := c.Set(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	got, err := c.Get(ctx, "k")
+	if err != nil || got != "v" {
+		t.Errorf("expected v, got %q (err: %v)", got, err)
+	}
+
+	if err := c.Delete(ctx, "k"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if ok, _ := c.Exists(ctx, "k"); ok {
+		t.Errorf("expected key to be deleted")
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	c := newTestMemoryCache(&now)
+
+	_ = c.Set(ctx, "k", "v", 10*time.Second)
+	_ = c.Set(ctx, "forever", "v", 0)
+
+	ttl, err := c.TTL(ctx, "k")
+	if err != nil || ttl != 10*time.Second {
+		t.Errorf("expected ttl 10s, got %v (err: %v)", ttl, err)
+	}
+	if ttl, _ := c.TTL(ctx, "forever"); ttl != NoExpiry {
+		t.Errorf("expected NoExpiry, got %v", ttl)
+	}
+
+	now = now.Add(10 * time.Second)
+
+	if _, err := c.Get(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected expired key to miss, got %v", err)
+	}
+	if _, err := c.TTL(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected TTL of expired key to miss, got %v", err)
+	}
+	if ok, _ := c.Exists(ctx, "forever"); !ok {
+		t.Errorf("expected key without ttl to survive")
+	}
+}
+
+func TestMemoryCache_SetNX(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+
+	ok, err := c.SetNX(ctx, "lock", "a", time.Minute)
+	if err != nil || !ok {
+		t.Fatalf("expected first SetNX to succeed, got %v (err: %v)", ok, err)
+	}
+	ok, _ = c.SetNX(ctx, "lock", "b", time.Minute)
+	if ok {
+		t.Errorf("expected second SetNX to fail")
+	}
+	if got, _ := c.Get(ctx, "lock"); got != "a" {
+		t.Errorf("expected value to stay a, got %q", got)
+	}
+}
+
+func TestMemoryCache_MGetMSet(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache()
+
+	err := c.MSet(ctx, map[string]string{"a": "1", "b": "2"}, time.Minute)
+	if err != nil {
+		t.Fatalf("mset failed: %v", err)
+	}
+
+	got, err := c.MGet(ctx, "a", "b", "c")
+	if err != nil {
+		t.Fatalf("mget failed: %v", err)
+	}
+	if len(got) != 2 || got["a"] != "1" || got["b"] != "2" {
+		t.Errorf("unexpected mget result: %v", got)
+	}
+	if _, ok := got["c"]; ok {
+		t.Errorf("expected missing key to be left out")
+	}
+}
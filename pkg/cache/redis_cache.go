@@ -2,35 +2,225 @@ package cache
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-type redisCache struct {
+// RedisConfig holds the connection settings for NewRedisCacheFromConfig.
+// Zero values fall back to the go-redis defaults.
+type RedisConfig struct {
+	Addr          string
+	Username      string
+	Password      string
+	DB            int
+	TLSEnabled    bool
+	TLSSkipVerify bool
+	PoolSize      int
+	DialTimeout   time.Duration
+	ReadTimeout   time.Duration
+	WriteTimeout  time.Duration
+}
+
+func (c RedisConfig) validate() error {
+	var errs []error
+	if c.Addr == "" {
+		errs = append(errs, errors.New("redis: addr is required"))
+	}
+	if c.DB < 0 {
+		errs = append(errs, fmt.Errorf("redis: db must not be negative, got %d", c.DB))
+	}
+	if c.Username != "" && c.Password == "" {
+		errs = append(errs, errors.New("redis: username is set but password is empty"))
+	}
+	if c.TLSSkipVerify && !c.TLSEnabled {
+		errs = append(errs, errors.New("redis: tls skip verify is set but tls is not enabled"))
+	}
+	if c.PoolSize < 0 {
+		errs = append(errs, fmt.Errorf("redis: pool size must not be negative, got %d", c.PoolSize))
+	}
+	if c.DialTimeout < 0 || c.ReadTimeout < 0 || c.WriteTimeout < 0 {
+		errs = append(errs, errors.New("redis: timeouts must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+type RedisCache struct {
 	client *redis.Client
+	// compressThreshold is the value size in bytes from which values are gzipped, 0 disables compression
+	compressThreshold int
+}
+
+type RedisOption func(*RedisCache)
+
+// WithCompression gzips values of at least threshold bytes. Smaller values are stored raw.
+// Entries written without compression can still be read after enabling it.
+func WithCompression(threshold int) RedisOption {
+	return func(r *RedisCache) {
+		r.compressThreshold = threshold
+	}
 }
 
 // NewRedisCache returns a Cache implemented with Redis
-func NewRedisCache(addr string) Cache {
-	rdb := redis.NewClient(&redis.Options{
-		Addr: addr,
-	})
-	return &redisCache{client: rdb}
+func NewRedisCache(addr string, opts ...RedisOption) Cache {
+	return newRedisCache(&redis.Options{Addr: addr}, opts)
+}
+
+// NewRedisCacheFromConfig returns a Redis backed cache for servers that need auth, TLS or tuning.
+// The config is validated up front so a bad setup fails at startup rather than on the first request.
+func NewRedisCacheFromConfig(cfg RedisConfig, opts ...RedisOption) (*RedisCache, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	options := &redis.Options{
+		Addr:         cfg.Addr,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+	if cfg.TLSEnabled {
+		options.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: cfg.TLSSkipVerify, // opt-in, for managed instances with self-signed certs
+		}
+	}
+
+	return newRedisCache(options, opts), nil
+}
+
+func newRedisCache(options *redis.Options, opts []RedisOption) *RedisCache {
+	r := &RedisCache{client: redis.NewClient(options)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Ping checks that Redis is reachable, used by the readiness probe
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
 }
 
-func (r *redisCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
-	return r.client.Set(ctx, key, value, ttl).Err()
+func (r *RedisCache) encode(value string) (string, error) {
+	if r.compressThreshold <= 0 {
+		return value, nil
+	}
+	return encodeValue(value, r.compressThreshold)
 }
 
-func (r *redisCache) SetNX(ctx context.Context, key string, value string, ttl time.Duration) error {
-	return r.client.SetNX(ctx, key, value, ttl).Err()
+func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
+	val, err := r.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrCacheMiss
+	}
+	if err != nil {
+		return "", err
+	}
+	return decodeValue(val)
 }
 
-func (r *redisCache) Get(ctx context.Context, key string) (string, error) {
-	return r.client.Get(ctx, key).Result()
+func (r *RedisCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	encoded, err := r.encode(value)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, encoded, ttl).Err()
 }
 
-func (r *redisCache) Del(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+func (r *RedisCache) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	encoded, err := r.encode(value)
+	if err != nil {
+		return false, err
+	}
+	return r.client.SetNX(ctx, key, encoded, ttl).Result()
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *RedisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := r.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Redis replies -2 for a missing key and -1 for a key without expiry
+	switch ttl {
+	case -2:
+		return 0, ErrCacheMiss
+	case -1:
+		return NoExpiry, nil
+	}
+	return ttl, nil
+}
+
+func (r *RedisCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	vals, err := r.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range vals {
+		// Missing keys come back as nil
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		decoded, err := decodeValue(s)
+		if err != nil {
+			return nil, err
+		}
+		result[keys[i]] = decoded
+	}
+	return result, nil
+}
+
+// MSet writes all values in a single MULTI/EXEC round trip.
+// Plain MSET can't carry a TTL, so each key gets its own SET.
+func (r *RedisCache) MSet(ctx context.Context, values map[string]string, ttl time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	encoded := make(map[string]string, len(values))
+	for k, v := range values {
+		e, err := r.encode(v)
+		if err != nil {
+			return err
+		}
+		encoded[k] = e
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for k, v := range encoded {
+			pipe.Set(ctx, k, v, ttl)
+		}
+		return nil
+	})
+	return err
 }
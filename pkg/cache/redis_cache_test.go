@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedisCache(t testing.TB, opts ...RedisOption) (Cache, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return NewRedisCache(mr.Addr(), opts...), mr
+}
+
+// largePayload builds a FlightSearchResponse-like JSON blob of roughly n flights
+func largePayload(n int) string {
+	var sb strings.Builder
+	sb.WriteString(`{"metadata":{"total_results":` + fmt.Sprint(n) + `},"flights":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":"GA%d_GarudaIndonesia","provider":"Garuda Indonesia","airline":{"name":"Garuda Indonesia","code":"GA"},`+
+			`"flight_number":"GA%d","departure":{"airport":"CGK","city":"Jakarta","datetime":"2025-12-15T06:00:00+07:00","timestamp":1765753200},`+
+			`"arrival":{"airport":"DPS","city":"Denpasar","datetime":"2025-12-15T08:50:00+08:00","timestamp":1765759800},`+
+			`"duration":{"total_minutes":110,"formatted":"1h 50m"},"stops":0,"price":{"amount":%d,"currency":"IDR"},`+
+			`"available_seats":28,"cabin_class":"economy","aircraft":"Boeing 737-800","amenities":["wifi","meal"],`+
+			`"baggage":{"carry_on":"Cabin: 1","checked":"Checked: 2"}}`, i, i, 1000000+i*1000)
+	}
+	sb.WriteString(`]}`)
+	return sb.String()
+}
+
+func TestRedisCache_CompressionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c, mr := newTestRedisCache(t, WithCompression(1024))
+
+	large := largePayload(100)
+	small := `{"flights":[]}`
+
+	if err := c.Set(ctx, "large", large, time.Minute); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+	if err := c.Set(ctx, "small", small, time.Minute); err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	stored, _ := mr.Get("large")
+	if stored[0] != encodingGzip || len(stored) >= len(large) {
+		t.Errorf("expected large value to be stored gzipped and smaller, got %d bytes (raw %d)", len(stored), len(large))
+	}
+	stored, _ = mr.Get("small")
+	if stored[0] != encodingRaw {
+		t.Errorf("expected small value to be stored raw below threshold")
+	}
+
+	for key, want := range map[string]string{"large": large, "small": small} {
+		got, err := c.Get(ctx, key)
+		if err != nil || got != want {
+			t.Errorf("round trip mismatch for %s (err: %v)", key, err)
+		}
+	}
+
+	got, err := c.MGet(ctx, "large", "small")
+	if err != nil || got["large"] != large || got["small"] != small {
+		t.Errorf("mget round trip mismatch (err: %v)", err)
+	}
+}
+
+func TestRedisCache_ReadsLegacyUncompressedEntries(t *testing.T) {
+	ctx := context.Background()
+	c, mr := newTestRedisCache(t, WithCompression(1))
+
+	legacy := `{"metadata":{"total_results":0},"flights":[]}`
+	if err := mr.Set("legacy", legacy); err != nil {
+		t.Fatalf("miniredis set failed: %v", err)
+	}
+
+	got, err := c.Get(ctx, "legacy")
+	if err != nil || got != legacy {
+		t.Errorf("expected legacy entry to decode as-is, got %q (err: %v)", got, err)
+	}
+}
+
+func TestRedisCache_CompressionDisabledStoresRaw(t *testing.T) {
+	ctx := context.Background()
+	c, mr := newTestRedisCache(t)
+
+	value := largePayload(10)
+	_ = c.Set(ctx, "k", value, time.Minute)
+
+	stored, _ := mr.Get("k")
+	if stored != value {
+		t.Errorf("expected value stored without header when compression is disabled")
+	}
+}
+
+func BenchmarkRedisCache_SerializedSize(b *testing.B) {
+	value := largePayload(500)
+	for b.Loop() {
+		encoded, err := encodeValue(value, 1024)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.ReportMetric(float64(len(value)), "raw_bytes")
+		b.ReportMetric(float64(len(encoded)), "gzip_bytes")
+	}
+}
+
+func benchmarkRedisSetGet(b *testing.B, opts ...RedisOption) {
+	ctx := context.Background()
+	c, _ := newTestRedisCache(b, opts...)
+	value := largePayload(500)
+
+	for b.Loop() {
+		if err := c.Set(ctx, "k", value, time.Minute); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := c.Get(ctx, "k"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRedisCache_SetGet_Raw(b *testing.B) {
+	benchmarkRedisSetGet(b)
+}
+
+func BenchmarkRedisCache_SetGet_Gzip(b *testing.B) {
+	benchmarkRedisSetGet(b, WithCompression(1024))
+}
+
+func TestRedisConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RedisConfig
+		wantErr string
+	}{
+		{name: "minimal", cfg: RedisConfig{Addr: "localhost:6379"}},
+		{name: "missing addr", cfg: RedisConfig{}, wantErr: "addr is required"},
+		{name: "negative db", cfg: RedisConfig{Addr: "localhost:6379", DB: -1}, wantErr: "db"},
+		{name: "username without password", cfg: RedisConfig{Addr: "localhost:6379", Username: "app"}, wantErr: "password"},
+		{name: "skip verify without tls", cfg: RedisConfig{Addr: "localhost:6379", TLSSkipVerify: true}, wantErr: "tls"},
+		{name: "negative pool size", cfg: RedisConfig{Addr: "localhost:6379", PoolSize: -1}, wantErr: "pool"},
+		{name: "negative timeout", cfg: RedisConfig{Addr: "localhost:6379", ReadTimeout: -time.Second}, wantErr: "timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRedisCacheFromConfig(tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRedisCache_PingWithAuth(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	mr.RequireUserAuth("app", "secret")
+
+	r, err := NewRedisCacheFromConfig(RedisConfig{Addr: mr.Addr(), Username: "app", Password: "secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Ping(ctx); err != nil {
+		t.Errorf("expected ping to succeed with credentials, got %v", err)
+	}
+
+	wrong, err := NewRedisCacheFromConfig(RedisConfig{Addr: mr.Addr(), Username: "app", Password: "wrong"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := wrong.Ping(ctx); err == nil {
+		t.Errorf("expected ping to fail with wrong password")
+	}
+}
+
+func TestRedisCache_SelectsDB(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	r, err := NewRedisCacheFromConfig(RedisConfig{Addr: mr.Addr(), DB: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Set(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if got, _ := mr.DB(2).Get("k"); got != "v" {
+		t.Errorf("expected key in db 2, got %q", got)
+	}
+	if mr.Exists("k") {
+		t.Errorf("expected key absent from db 0")
+	}
+}
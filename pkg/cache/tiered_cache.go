@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+	"travel/pkg/logger"
+)
+
+// TieredCache puts a bounded in-process LRU in front of a remote cache (Redis).
+// Reads try the local layer first and writes go to both. When the remote layer
+// errors, the local layer keeps serving and the failure is logged and counted
+// instead of being returned to the caller. SetNX is the exception, see below.
+type TieredCache struct {
+	local          *lru
+	remote         Cache
+	logger         logger.Client
+	remoteFailures atomic.Uint64
+}
+
+var _ Cache = (*TieredCache)(nil)
+
+// NewTieredCache wraps remote with a local LRU holding up to size entries, each kept for at most localTTL.
+func NewTieredCache(remote Cache, size int, localTTL time.Duration, logger logger.Client) *TieredCache {
+	return &TieredCache{
+		local:  newLRU(size, localTTL),
+		remote: remote,
+		logger: logger,
+	}
+}
+
+// RemoteFailures returns how many remote cache operations failed and were absorbed by the local layer
+func (t *TieredCache) RemoteFailures() uint64 {
+	return t.remoteFailures.Load()
+}
+
+func (t *TieredCache) remoteFailed(op string, err error) {
+	t.remoteFailures.Add(1)
+	t.logger.Warn("remote_cache_unavailable",
+		logger.Field{Key: "op", Value: op},
+		logger.Field{Key: "err", Value: err.Error()})
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string) (string, error) {
+	if val, ok := t.local.get(key); ok {
+		return val, nil
+	}
+
+	val, err := t.remote.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			t.remoteFailed("get", err)
+		}
+		return "", ErrCacheMiss
+	}
+
+	// Only the remaining remote TTL is known after a read, fall back to the local TTL cap
+	t.local.set(key, val, 0)
+	return val, nil
+}
+
+func (t *TieredCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	t.local.set(key, value, ttl)
+	if err := t.remote.Set(ctx, key, value, ttl); err != nil {
+		t.remoteFailed("set", err)
+	}
+	return nil
+}
+
+// SetNX is used for cross-instance locks, so only the remote layer may grant them.
+// A local fallback would let every instance hold the same lock while Redis is down.
+func (t *TieredCache) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	ok, err := t.remote.SetNX(ctx, key, value, ttl)
+	if err != nil {
+		t.remoteFailed("setnx", err)
+		return false, err
+	}
+	if ok {
+		t.local.set(key, value, ttl)
+	}
+	return ok, nil
+}
+
+func (t *TieredCache) Delete(ctx context.Context, keys ...string) error {
+	for _, k := range keys {
+		t.local.delete(k)
+	}
+	if err := t.remote.Delete(ctx, keys...); err != nil {
+		t.remoteFailed("delete", err)
+	}
+	return nil
+}
+
+func (t *TieredCache) Exists(ctx context.Context, key string) (bool, error) {
+	if _, ok := t.local.get(key); ok {
+		return true, nil
+	}
+
+	ok, err := t.remote.Exists(ctx, key)
+	if err != nil {
+		t.remoteFailed("exists", err)
+		return false, nil
+	}
+	return ok, nil
+}
+
+func (t *TieredCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := t.remote.TTL(ctx, key)
+	if err == nil || errors.Is(err, ErrCacheMiss) {
+		return ttl, err
+	}
+
+	t.remoteFailed("ttl", err)
+	if remaining, ok := t.local.remainingTTL(key); ok {
+		return remaining, nil
+	}
+	return 0, ErrCacheMiss
+}
+
+func (t *TieredCache) MGet(ctx context.Context, keys ...string) (map[string]string, error) {
+	result := make(map[string]string, len(keys))
+	missing := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if val, ok := t.local.get(k); ok {
+			result[k] = val
+			continue
+		}
+		missing = append(missing, k)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	remote, err := t.remote.MGet(ctx, missing...)
+	if err != nil {
+		t.remoteFailed("mget", err)
+		return result, nil
+	}
+	for k, v := range remote {
+		t.local.set(k, v, 0)
+		result[k] = v
+	}
+	return result, nil
+}
+
+func (t *TieredCache) MSet(ctx context.Context, values map[string]string, ttl time.Duration) error {
+	for k, v := range values {
+		t.local.set(k, v, ttl)
+	}
+	if err := t.remote.MSet(ctx, values, ttl); err != nil {
+		t.remoteFailed("mset", err)
+	}
+	return nil
+}
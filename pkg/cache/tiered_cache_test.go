@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"travel/pkg/logger"
+)
+
+// failingCache simulates Redis being down
+type failingCache struct {
+	Cache
+}
+
+var errRemoteDown = errors.New("dial tcp: connection refused")
+
+func (failingCache) Get(ctx context.Context, key string) (string, error) {
+	return "", errRemoteDown
+}
+
+func (failingCache) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	return errRemoteDown
+}
+
+func (failingCache) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	return false, errRemoteDown
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errRemoteDown
+}
+
+func newTestTieredCache(remote Cache, size int, ttl time.Duration) (*TieredCache, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	return NewTieredCache(remote, size, ttl, logger.NewWithWriter("development", buf)), buf
+}
+
+func TestTieredCache_ReadsThroughAndFillsLocal(t *testing.T) {
+	ctx := context.Background()
+	remote := NewMemoryCache()
+	_ = remote.Set(ctx, "k", "v", time.Minute)
+
+	c, _ := newTestTieredCache(remote, 10, time.Minute)
+
+	got, err := c.Get(ctx, "k")
+	if err != nil || got != "v" {
+		t.Fatalf("expected v from remote, got %q (err: %v)", got, err)
+	}
+
+	// Removing it remotely proves the second read is served locally
+	_ = remote.Delete(ctx, "k")
+	if got, err := c.Get(ctx, "k"); err != nil || got != "v" {
+		t.Errorf("expected v from local layer, got %q (err: %v)", got, err)
+	}
+}
+
+func TestTieredCache_ServesLocalWhenRemoteDown(t *testing.T) {
+	ctx := context.Background()
+	c, buf := newTestTieredCache(failingCache{}, 10, time.Minute)
+
+	if err := c.Set(ctx, "k", "v", time.Minute); err != nil {
+		t.Fatalf("expected set to absorb remote failure, got %v", err)
+	}
+	got, err := c.Get(ctx, "k")
+	if err != nil || got != "v" {
+		t.Errorf("expected v from local layer, got %q (err: %v)", got, err)
+	}
+
+	if _, err := c.Get(ctx, "missing"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected remote failure to surface as a miss, got %v", err)
+	}
+
+	if c.RemoteFailures() != 2 {
+		t.Errorf("expected 2 remote failures, got %d", c.RemoteFailures())
+	}
+	if !strings.Contains(buf.String(), `"level":"warn"`) {
+		t.Errorf("expected warn log for remote failure, got: %s", buf.String())
+	}
+}
+
+func TestTieredCache_SetNXFailsWhenRemoteDown(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestTieredCache(failingCache{}, 10, time.Minute)
+
+	// A lock granted by the local layer alone would be held by every instance at once
+	ok, err := c.SetNX(ctx, "lock", "1", time.Minute)
+	if !errors.Is(err, errRemoteDown) || ok {
+		t.Errorf("expected setnx to return the remote error, got ok=%v err=%v", ok, err)
+	}
+	if c.RemoteFailures() != 1 {
+		t.Errorf("expected 1 remote failure, got %d", c.RemoteFailures())
+	}
+}
+
+func TestTieredCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestTieredCache(failingCache{}, 2, time.Minute)
+
+	_ = c.Set(ctx, "a", "1", time.Minute)
+	_ = c.Set(ctx, "b", "2", time.Minute)
+	_, _ = c.Get(ctx, "a") // a is now the most recently used
+	_ = c.Set(ctx, "c", "3", time.Minute)
+
+	if c.local.len() != 2 {
+		t.Errorf("expected local size 2, got %d", c.local.len())
+	}
+	if _, err := c.Get(ctx, "b"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected b to be evicted, got %v", err)
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, err := c.Get(ctx, k); err != nil {
+			t.Errorf("expected %s to stay cached, got %v", k, err)
+		}
+	}
+}
+
+func TestTieredCache_LocalEntryTTL(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestTieredCache(failingCache{}, 10, 5*time.Second)
+
+	now := time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)
+	c.local.now = func() time.Time { return now }
+
+	// The write TTL is longer than the local cap, so the local cap wins
+	_ = c.Set(ctx, "k", "v", time.Minute)
+
+	now = now.Add(4 * time.Second)
+	if _, err := c.Get(ctx, "k"); err != nil {
+		t.Errorf("expected entry to still be cached, got %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, err := c.Get(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("expected entry to expire after local ttl, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -15,13 +16,15 @@ type AirAsiaClient struct {
 	httpClient *http.Client
 	baseURL    string
 	logger     logger.Client
+	opts       clientOptions
 }
 
-func NewAirAsiaClient(httpClient *http.Client, baseURL string, logger logger.Client) *AirAsiaClient {
+func NewAirAsiaClient(httpClient *http.Client, baseURL string, logger logger.Client, opts ...ClientOption) *AirAsiaClient {
 	return &AirAsiaClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
 		logger:     logger,
+		opts:       newClientOptions(opts),
 	}
 }
 
@@ -48,9 +51,17 @@ type airAsiaFlight struct {
 	CabinClass    string        `json:"cabin_class"`
 	BaggageNote   string        `json:"baggage_note"`
 	Stops         []airAsiaStop `json:"stops"`
+	OfferID       string        `json:"offer_id"`
 }
 
 func (a *AirAsiaClient) SearchFlights(ctx context.Context, req flight.SearchRequest) (*airAsiaFlightResponse, error) {
+	if err := a.opts.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("airasia: %w", err)
+	}
+
+	ctx, span := startProviderSpan(ctx, "airasia", req)
+	defer span.End()
+
 	url := fmt.Sprintf("%s/airasia/v1/flights/search", a.baseURL)
 
 	reqBody, err := json.Marshal(req)
@@ -58,7 +69,7 @@ func (a *AirAsiaClient) SearchFlights(ctx context.Context, req flight.SearchRequ
 		return nil, fmt.Errorf("airasia: failed to marshal request: %w", err)
 	}
 
-	r, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(reqBody))
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("airasia: failed to build request: %w", err)
 	}
@@ -76,17 +87,42 @@ func (a *AirAsiaClient) SearchFlights(ctx context.Context, req flight.SearchRequ
 	}
 
 	var apiResp airAsiaFlightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decodeResponse(resp.Body, &apiResp, a.opts); err != nil {
 		return nil, fmt.Errorf("airasia: failed to decode json response: %w", err)
 	}
 
 	return &apiResp, nil
 }
 
-func (f *FlightManager) mapAirAsiaFlights(resp *airAsiaFlightResponse) []flight.Flight {
+// validateAirAsiaFlight checks the fields the mapping can't do without
+func validateAirAsiaFlight(f airAsiaFlight) error {
+	switch {
+	case len(f.FlightCode) < 2:
+		return errors.New("missing flight_code")
+	case f.DepartTime.IsZero():
+		return errors.New("missing depart_time")
+	case f.ArriveTime.IsZero():
+		return errors.New("missing arrive_time")
+	case f.PriceIDR == 0:
+		return errors.New("missing price_idr")
+	}
+	return nil
+}
+
+// mapAirAsiaFlights maps valid records to domain flights and returns how many invalid records were skipped
+func (f *FlightManager) mapAirAsiaFlights(resp *airAsiaFlightResponse) ([]flight.Flight, uint32) {
 	mapped := make([]flight.Flight, 0, len(resp.Flights))
+	skipped := uint32(0)
 
 	for _, aaFlight := range resp.Flights {
+		if err := validateAirAsiaFlight(aaFlight); err != nil {
+			f.logger.Warn("skipping invalid airasia flight",
+				logger.Field{Key: "flight_id", Value: aaFlight.FlightCode},
+				logger.Field{Key: "err", Value: err.Error()})
+			skipped++
+			continue
+		}
+
 		totalMinutes := uint32(math.Round(aaFlight.DurationHours * 60))
 		hours := totalMinutes / 60
 		minutes := totalMinutes % 60
@@ -101,8 +137,9 @@ func (f *FlightManager) mapAirAsiaFlights(resp *airAsiaFlightResponse) []flight.
 		}
 
 		domainFlight := flight.Flight{
-			ID:       aaFlight.FlightCode + "_" + aaFlight.Airline,
-			Provider: "AirAsia",
+			ID:         aaFlight.FlightCode + "_" + aaFlight.Airline,
+			OfferToken: aaFlight.OfferID,
+			Provider:   "AirAsia",
 			Airline: flight.Airline{
 				Name: aaFlight.Airline,
 				Code: aaFlight.FlightCode[0:2],
@@ -135,5 +172,5 @@ func (f *FlightManager) mapAirAsiaFlights(resp *airAsiaFlightResponse) []flight.
 		}
 		mapped = append(mapped, domainFlight)
 	}
-	return mapped
+	return mapped, skipped
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -16,13 +17,15 @@ type BatikAirClient struct {
 	httpClient *http.Client
 	baseURL    string
 	logger     logger.Client
+	opts       clientOptions
 }
 
-func NewBatikAirClient(httpClient *http.Client, baseURL string, logger logger.Client) *BatikAirClient {
+func NewBatikAirClient(httpClient *http.Client, baseURL string, logger logger.Client, opts ...ClientOption) *BatikAirClient {
 	return &BatikAirClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
 		logger:     logger,
+		opts:       newClientOptions(opts),
 	}
 }
 
@@ -47,6 +50,7 @@ type batikAirFlight struct {
 	AircraftModel     string       `json:"aircraftModel"`
 	BaggageInfo       string       `json:"baggageInfo"`
 	OnboardServices   []string     `json:"onboardServices"`
+	FareToken         string       `json:"fareToken"`
 }
 
 type fare struct {
@@ -58,6 +62,13 @@ type fare struct {
 }
 
 func (a *BatikAirClient) SearchFlights(ctx context.Context, req flight.SearchRequest) (*batikAirFlightResponse, error) {
+	if err := a.opts.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("batikair: %w", err)
+	}
+
+	ctx, span := startProviderSpan(ctx, "batikair", req)
+	defer span.End()
+
 	url := fmt.Sprintf("%s/batikair/v1/flights/search", a.baseURL)
 
 	reqBody, err := json.Marshal(req)
@@ -65,7 +76,7 @@ func (a *BatikAirClient) SearchFlights(ctx context.Context, req flight.SearchReq
 		return nil, fmt.Errorf("batikair: failed to marshal request: %w", err)
 	}
 
-	r, err := http.NewRequest(http.MethodGet, url, bytes.NewBuffer(reqBody))
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("batikair: failed to build request: %w", err)
 	}
@@ -81,22 +92,48 @@ func (a *BatikAirClient) SearchFlights(ctx context.Context, req flight.SearchReq
 	}
 
 	var apiResp batikAirFlightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decodeResponse(resp.Body, &apiResp, a.opts); err != nil {
 		return nil, fmt.Errorf("batikair: failed to decode batik response: %w", err)
 	}
 
 	return &apiResp, nil
 }
 
-func (f *FlightManager) mapBatikFlights(resp *batikAirFlightResponse) []flight.Flight {
+// validateBatikFlight checks the fields the mapping can't do without
+func validateBatikFlight(f batikAirFlight) error {
+	switch {
+	case f.FlightNumber == "":
+		return errors.New("missing flightNumber")
+	case f.DepartureDateTime.IsZero():
+		return errors.New("missing departureDateTime")
+	case f.ArrivalDateTime.IsZero():
+		return errors.New("missing arrivalDateTime")
+	case f.Fare.TotalPrice == 0:
+		return errors.New("missing fare.totalPrice")
+	}
+	return nil
+}
+
+// mapBatikFlights maps valid records to domain flights and returns how many invalid records were skipped
+func (f *FlightManager) mapBatikFlights(resp *batikAirFlightResponse) ([]flight.Flight, uint32) {
 	mapped := make([]flight.Flight, 0, len(resp.Results))
+	skipped := uint32(0)
 
 	for _, btFlight := range resp.Results {
+		if err := validateBatikFlight(btFlight); err != nil {
+			f.logger.Warn("skipping invalid batik flight",
+				logger.Field{Key: "flight_id", Value: btFlight.FlightNumber},
+				logger.Field{Key: "err", Value: err.Error()})
+			skipped++
+			continue
+		}
+
 		totalMinutes, formattedDuration := f.parseBatikDuration(btFlight.TravelTime)
 
 		domainFlight := flight.Flight{
-			ID:       btFlight.FlightNumber + "_" + "BatikAir",
-			Provider: btFlight.AirlineName,
+			ID:         btFlight.FlightNumber + "_" + "BatikAir",
+			OfferToken: btFlight.FareToken,
+			Provider:   btFlight.AirlineName,
 			Airline: flight.Airline{
 				Name: btFlight.AirlineName,
 				Code: btFlight.AirlineIATA,
@@ -131,7 +168,7 @@ func (f *FlightManager) mapBatikFlights(resp *batikAirFlightResponse) []flight.F
 		}
 		mapped = append(mapped, domainFlight)
 	}
-	return mapped
+	return mapped, skipped
 }
 
 func (f *FlightManager) parseBatikDuration(input string) (uint32, string) {
@@ -0,0 +1,57 @@
+package flightclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"travel/internal/flight"
+	"travel/pkg/logger"
+)
+
+func newEmptyBodyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSearchFlights_EmptyBodyIsZeroFlights(t *testing.T) {
+	srv := newEmptyBodyServer(t)
+	log := logger.NewWithWriter("development", &bytes.Buffer{})
+	ctx := context.Background()
+	req := flight.SearchRequest{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+
+	manager := NewFlightClient(
+		NewAirAsiaClient(srv.Client(), srv.URL, log),
+		NewBatikAirClient(srv.Client(), srv.URL, log),
+		NewGarudaClient(srv.Client(), srv.URL, log),
+		NewLionAirClient(srv.Client(), srv.URL, log),
+		log,
+	)
+
+	resp, err := manager.SearchFlights(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Flights) != 0 {
+		t.Errorf("expected zero flights, got %d", len(resp.Flights))
+	}
+	if resp.Metadata.ProvidersSucceeded != 4 || resp.Metadata.ProvidersFailed != 0 {
+		t.Errorf("expected all 4 providers to succeed, got %d succeeded and %d failed",
+			resp.Metadata.ProvidersSucceeded, resp.Metadata.ProvidersFailed)
+	}
+}
+
+func TestSearchFlights_EmptyBodyAsErrorWhenConfigured(t *testing.T) {
+	srv := newEmptyBodyServer(t)
+	log := logger.NewWithWriter("development", &bytes.Buffer{})
+	client := NewAirAsiaClient(srv.Client(), srv.URL, log, WithEmptyBodyAsError(true))
+
+	if _, err := client.SearchFlights(context.Background(), flight.SearchRequest{}); err == nil {
+		t.Errorf("expected empty body to fail when configured as error")
+	}
+}
@@ -0,0 +1,74 @@
+package flightclient
+
+import (
+	"testing"
+)
+
+func FuzzUnmarshalFlexibleTime(f *testing.F) {
+	seeds := []string{
+		`"2025-12-15T06:00:00+07:00"`, // RFC3339 (AirAsia, Garuda)
+		`"2025-12-15T07:15:00+0700"`,  // Batik Air
+		`"2025-12-15T05:30:00"`,       // Lion Air
+		`""`,
+		`1734245400`,
+		`"1734245400"`,
+		`null`,
+		`"2025-12-15"`,
+		`"0001-01-01T00:00:00Z"`,
+		`"`,
+		`{}`,
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var ft FlexibleTime
+		err := ft.UnmarshalJSON(b)
+
+		if string(b) == "null" {
+			if err != nil || !ft.IsZero() {
+				t.Errorf("expected null to leave zero time without error, got %v (err: %v)", ft.Time, err)
+			}
+			return
+		}
+		if err == nil && ft.IsZero() {
+			t.Errorf("input %q returned zero time without error", b)
+		}
+	})
+}
+
+func TestFlexibleTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "rfc3339", input: `"2025-12-15T06:00:00+07:00"`},
+		{name: "batik offset", input: `"2025-12-15T07:15:00+0700"`},
+		{name: "no timezone", input: `"2025-12-15T05:30:00"`},
+		{name: "empty string", input: `""`, wantErr: true},
+		{name: "unix int", input: `1734245400`, wantErr: true},
+		{name: "date only", input: `"2025-12-15"`, wantErr: true},
+		{name: "zero time", input: `"0001-01-01T00:00:00Z"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ft FlexibleTime
+			err := ft.UnmarshalJSON([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %s, got %v", tt.input, ft.Time)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error for %s: %v", tt.input, err)
+			}
+			if ft.IsZero() {
+				t.Errorf("expected non-zero time for %s", tt.input)
+			}
+		})
+	}
+}
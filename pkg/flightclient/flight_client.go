@@ -2,13 +2,16 @@ package flightclient
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
 	"travel/internal/flight"
 	"travel/pkg/logger"
+	"travel/pkg/synthetic"
 )
 
 type FlightManager struct {
@@ -17,24 +20,81 @@ type FlightManager struct {
 	garudaClient   *GarudaClient
 	lionAirClient  *LionAirClient
 	logger         logger.Client
+	coverage       map[string]RouteCoverage
+}
+
+// ManagerOption configures the FlightManager
+type ManagerOption func(*FlightManager)
+
+// RouteCoverage lists the airports (IATA codes) a provider flies from and to.
+// An empty list means the provider is queried for every route.
+type RouteCoverage struct {
+	Airports []string
+}
+
+// serves reports whether both ends of the route are airports the provider flies to
+func (rc RouteCoverage) serves(origin, destination string) bool {
+	if len(rc.Airports) == 0 {
+		return true
+	}
+	var originServed, destinationServed bool
+	for _, airport := range rc.Airports {
+		originServed = originServed || strings.EqualFold(airport, origin)
+		destinationServed = destinationServed || strings.EqualFold(airport, destination)
+	}
+	return originServed && destinationServed
+}
+
+// WithRouteCoverage skips the provider for routes outside its coverage instead of querying it
+func WithRouteCoverage(provider string, coverage RouteCoverage) ManagerOption {
+	return func(f *FlightManager) {
+		f.coverage[provider] = coverage
+	}
 }
 
 func NewFlightClient(airAsiaClient *AirAsiaClient, batikAirClient *BatikAirClient,
-	garudaClient *GarudaClient, lionAirClient *LionAirClient, logger logger.Client) *FlightManager {
-	return &FlightManager{
+	garudaClient *GarudaClient, lionAirClient *LionAirClient, logger logger.Client, opts ...ManagerOption) *FlightManager {
+	f := &FlightManager{
 		airAsiaClient:  airAsiaClient,
 		batikAirClient: batikAirClient,
 		garudaClient:   garudaClient,
 		lionAirClient:  lionAirClient,
 		logger:         logger,
+		coverage:       make(map[string]RouteCoverage),
+	}
+	for _, opt := range opts {
+		opt(f)
 	}
+	return f
 }
 
+// Provider names, as shown in Flight.Provider and used as keys for per-provider settings
+const (
+	ProviderAirAsia  = "AirAsia"
+	ProviderBatikAir = "Batik Air"
+	ProviderGaruda   = "Garuda Indonesia"
+	ProviderLionAir  = "Lion Air"
+)
+
 type providerResult struct {
-	provider  string
-	flights   []flight.Flight
-	err       error
-	errorCode flight.ErrorCode
+	provider string
+	flights  []flight.Flight
+	skipped  uint32
+	err      *flight.ProviderUnavailableError
+}
+
+type providerSearch struct {
+	name   string
+	search func(ctx context.Context, req flight.SearchRequest) providerResult
+}
+
+func (f *FlightManager) providers() []providerSearch {
+	return []providerSearch{
+		{name: ProviderAirAsia, search: f.searchAirAsia},
+		{name: ProviderBatikAir, search: f.searchBatikAir},
+		{name: ProviderGaruda, search: f.searchGaruda},
+		{name: ProviderLionAir, search: f.searchLionAir},
+	}
 }
 
 func (f *FlightManager) SearchFlights(ctx context.Context, req flight.SearchRequest) (*flight.FlightSearchResponse, error) {
@@ -42,68 +102,27 @@ func (f *FlightManager) SearchFlights(ctx context.Context, req flight.SearchRequ
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	resultChan := make(chan providerResult, 4)
+	providers := f.providers()
+	resultChan := make(chan providerResult, len(providers))
 	var wg sync.WaitGroup
 
-	wg.Add(4)
-
-	go func() {
-		defer wg.Done()
-		resp, err := f.airAsiaClient.SearchFlights(ctx, req)
-		if err != nil {
-			errCode := categorizeError(err)
-			f.logger.Error("failed to fetch airasia", logger.Field{Key: "err", Value: err.Error()})
-			resultChan <- providerResult{provider: "AirAsia", err: err, errorCode: errCode}
-			return
+	providersQueried := uint32(0)
+	providersSkipped := uint32(0)
+	for _, p := range providers {
+		if coverage, ok := f.coverage[p.name]; ok && !coverage.serves(req.Origin, req.Destination) {
+			f.logger.Debug("provider_skipped_route", logger.Field{Key: "provider", Value: p.name},
+				logger.Field{Key: "origin", Value: req.Origin}, logger.Field{Key: "destination", Value: req.Destination},
+				synthetic.LogField(ctx))
+			providersSkipped++
+			continue
 		}
-		flights := f.mapAirAsiaFlights(resp)
-		resultChan <- providerResult{provider: "AirAsia", flights: flights}
-	}()
-
-	go func() {
-		defer wg.Done()
-		resp, err := f.batikAirClient.SearchFlights(ctx, req)
-		if err != nil {
-			errCode := categorizeError(err)
-			f.logger.Error("failed to fetch batik", logger.Field{Key: "err", Value: err.Error()})
-			resultChan <- providerResult{provider: "Batik Air", err: err, errorCode: errCode}
-			return
-		}
-		flights := f.mapBatikFlights(resp)
-		resultChan <- providerResult{provider: "Batik Air", flights: flights}
-	}()
-
-	go func() {
-		defer wg.Done()
-		resp, err := f.garudaClient.SearchFlights(ctx, req)
-		if err != nil {
-			errCode := categorizeError(err)
-			f.logger.Error("failed to fetch garuda", logger.Field{Key: "err", Value: err.Error()})
-			resultChan <- providerResult{provider: "Garuda Indonesia", err: err, errorCode: errCode}
-			return
-		}
-		flights := f.mapGarudaFlights(resp)
-		resultChan <- providerResult{provider: "Garuda Indonesia", flights: flights}
-	}()
-
-	go func() {
-		defer wg.Done()
-		resp, err := f.lionAirClient.SearchFlights(ctx, req)
-		if err != nil {
-			errCode := categorizeError(err)
-			f.logger.Error("failed to fetch lion air", logger.Field{Key: "err", Value: err.Error()})
-			resultChan <- providerResult{provider: "Lion Air", err: err, errorCode: errCode}
-			return
-		}
-		flights, err := f.mapLionAirFlights(resp)
-		if err != nil {
-			errCode := categorizeError(err)
-			f.logger.Error("failed to map lion air flights", logger.Field{Key: "err", Value: err.Error()})
-			resultChan <- providerResult{provider: "Lion Air", err: err, errorCode: errCode}
-			return
-		}
-		resultChan <- providerResult{provider: "Lion Air", flights: flights}
-	}()
+		providersQueried++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resultChan <- p.search(ctx, req)
+		}()
+	}
 
 	go func() {
 		wg.Wait()
@@ -114,15 +133,22 @@ func (f *FlightManager) SearchFlights(ctx context.Context, req flight.SearchRequ
 	var providerErrors []flight.ProviderError
 	providersSucceeded := uint32(0)
 	providersFailed := uint32(0)
-	providersQueried := uint32(4)
+	skippedFlights := uint32(0)
 
-	for i := 0; i < 4; i++ {
+	for i := uint32(0); i < providersQueried; i++ {
 		select {
 		case result := <-resultChan:
 			if result.err == nil {
 				allFlights = append(allFlights, result.flights...)
+				skippedFlights += result.skipped
 				providersSucceeded++
+				continue
 			}
+			providersFailed++
+			providerErrors = append(providerErrors, flight.ProviderError{
+				Provider: result.provider,
+				Code:     result.err.Code(),
+			})
 		case <-ctx.Done():
 			// The overall time limit (10s) was hit before we finished the loop
 			return nil, ctx.Err()
@@ -136,24 +162,126 @@ func (f *FlightManager) SearchFlights(ctx context.Context, req flight.SearchRequ
 			ProvidersQueried:   providersQueried,
 			ProvidersSucceeded: providersSucceeded,
 			ProvidersFailed:    providersFailed,
+			ProvidersSkipped:   providersSkipped,
 			ProviderErrors:     providerErrors,
+			SkippedFlights:     skippedFlights,
 		},
 	}, nil
 }
 
-func categorizeError(err error) flight.ErrorCode {
+func (f *FlightManager) searchAirAsia(ctx context.Context, req flight.SearchRequest) providerResult {
+	resp, err := f.airAsiaClient.SearchFlights(ctx, req)
+	if err != nil {
+		f.logger.Error("failed to fetch airasia", logger.Field{Key: "err", Value: err.Error()}, synthetic.LogField(ctx))
+		return providerResult{provider: ProviderAirAsia, err: categorizeError(ProviderAirAsia, err)}
+	}
+	flights, skipped := f.mapAirAsiaFlights(resp)
+	return providerResult{provider: ProviderAirAsia, flights: flights, skipped: skipped}
+}
+
+func (f *FlightManager) searchBatikAir(ctx context.Context, req flight.SearchRequest) providerResult {
+	resp, err := f.batikAirClient.SearchFlights(ctx, req)
+	if err != nil {
+		f.logger.Error("failed to fetch batik", logger.Field{Key: "err", Value: err.Error()}, synthetic.LogField(ctx))
+		return providerResult{provider: ProviderBatikAir, err: categorizeError(ProviderBatikAir, err)}
+	}
+	flights, skipped := f.mapBatikFlights(resp)
+	return providerResult{provider: ProviderBatikAir, flights: flights, skipped: skipped}
+}
+
+func (f *FlightManager) searchGaruda(ctx context.Context, req flight.SearchRequest) providerResult {
+	resp, err := f.garudaClient.SearchFlights(ctx, req)
+	if err != nil {
+		f.logger.Error("failed to fetch garuda", logger.Field{Key: "err", Value: err.Error()}, synthetic.LogField(ctx))
+		return providerResult{provider: ProviderGaruda, err: categorizeError(ProviderGaruda, err)}
+	}
+	flights, skipped := f.mapGarudaFlights(resp)
+	return providerResult{provider: ProviderGaruda, flights: flights, skipped: skipped}
+}
+
+func (f *FlightManager) searchLionAir(ctx context.Context, req flight.SearchRequest) providerResult {
+	resp, err := f.lionAirClient.SearchFlights(ctx, req)
+	if err != nil {
+		f.logger.Error("failed to fetch lion air", logger.Field{Key: "err", Value: err.Error()}, synthetic.LogField(ctx))
+		return providerResult{provider: ProviderLionAir, err: categorizeError(ProviderLionAir, err)}
+	}
+	flights, skipped, err := f.mapLionAirFlights(resp)
+	if err != nil {
+		f.logger.Error("failed to map lion air flights", logger.Field{Key: "err", Value: err.Error()}, synthetic.LogField(ctx))
+		return providerResult{provider: ProviderLionAir, err: categorizeError(ProviderLionAir, err)}
+	}
+	return providerResult{provider: ProviderLionAir, flights: flights, skipped: skipped}
+}
+
+type clientOptions struct {
+	emptyBodyAsError bool
+	rateQPS          float64
+	rateBurst        int
+	rateFailFast     bool
+	// limiter is built from the rate settings, nil when the provider isn't rate limited
+	limiter *tokenBucket
+}
+
+// ClientOption configures the provider clients
+type ClientOption func(*clientOptions)
+
+// WithEmptyBodyAsError makes a 200 response with an empty body fail the provider
+// instead of counting as "no flights"
+func WithEmptyBodyAsError(enabled bool) ClientOption {
+	return func(o *clientOptions) {
+		o.emptyBodyAsError = enabled
+	}
+}
+
+// WithRateLimit caps calls to the provider at qps requests per second, allowing bursts of up to burst.
+// Calls over the limit wait for a free slot (bounded by the request context), or fail right away
+// with ErrRateLimited when failFast is set. A qps of 0 disables the limit.
+func WithRateLimit(qps float64, burst int, failFast bool) ClientOption {
+	return func(o *clientOptions) {
+		o.rateQPS = qps
+		o.rateBurst = burst
+		o.rateFailFast = failFast
+	}
+}
+
+func newClientOptions(opts []ClientOption) clientOptions {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.rateQPS > 0 {
+		o.limiter = newTokenBucket(o.rateQPS, o.rateBurst, o.rateFailFast)
+	}
+	return o
+}
+
+// decodeResponse decodes a provider body. Some providers answer 200 with an empty
+// body when nothing matches, that is treated as zero flights unless configured otherwise.
+func decodeResponse(body io.Reader, v any, opts clientOptions) error {
+	err := json.NewDecoder(body).Decode(v)
+	if errors.Is(err, io.EOF) && !opts.emptyBodyAsError {
+		return nil
+	}
+	return err
+}
+
+// categorizeError wraps a provider failure into a domain error, telling timeouts apart from other failures
+func categorizeError(provider string, err error) *flight.ProviderUnavailableError {
 	if err == nil {
-		return ""
+		return nil
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return flight.NewProviderUnavailableError(provider, flight.ErrorCodeRateLimited, err)
 	}
 	errMsg := err.Error()
 
 	if errors.Is(err, context.DeadlineExceeded) ||
 		strings.Contains(errMsg, "timeout") ||
 		strings.Contains(errMsg, "deadline exceeded") {
-		return flight.ErrorCodeTimeout
+		return flight.NewProviderUnavailableError(provider, flight.ErrorCodeTimeout, err)
 	}
 
-	return flight.ErrorCodeInternalFailure
+	return flight.NewProviderUnavailableError(provider, flight.ErrorCodeProviderFailed, err)
 }
 
 // FlexibleTime handles multiple time formats from different airline providers
@@ -162,7 +290,18 @@ type FlexibleTime struct {
 }
 
 func (ft *FlexibleTime) UnmarshalJSON(b []byte) error {
-	s := strings.Trim(string(b), "\"")
+	// Leave the zero value untouched for null, same as encoding/json does for time.Time
+	if string(b) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("unable to parse time: expected string, got %s", b)
+	}
+	if s == "" {
+		return errors.New("unable to parse time: empty string")
+	}
 
 	formats := []string{
 		time.RFC3339,               // Standard: 2006-01-02T15:04:05Z07:00 (AirAsia, Garuda)
@@ -172,6 +311,10 @@ func (ft *FlexibleTime) UnmarshalJSON(b []byte) error {
 
 	for _, format := range formats {
 		if t, err := time.Parse(format, s); err == nil {
+			// A provider sending 0001-01-01 means "no value", don't pass it on as a real time
+			if t.IsZero() {
+				return fmt.Errorf("unable to parse time: zero time %s", s)
+			}
 			ft.Time = t
 			return nil
 		}
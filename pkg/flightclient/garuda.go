@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"travel/internal/flight"
@@ -14,13 +15,15 @@ type GarudaClient struct {
 	httpClient *http.Client
 	baseURL    string
 	logger     logger.Client
+	opts       clientOptions
 }
 
-func NewGarudaClient(httpClient *http.Client, baseURL string, logger logger.Client) *GarudaClient {
+func NewGarudaClient(httpClient *http.Client, baseURL string, logger logger.Client, opts ...ClientOption) *GarudaClient {
 	return &GarudaClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
 		logger:     logger,
+		opts:       newClientOptions(opts),
 	}
 }
 
@@ -44,6 +47,9 @@ type garudaFlight struct {
 	Baggage         garudaBaggage   `json:"baggage"`
 	Amenities       []string        `json:"amenities"`
 	Segments        []garudaSegment `json:"segments,omitempty"`
+	OfferToken      string          `json:"offer_token"`
+	// MaxAdjacentSeats is only sent on routes where Garuda exposes the seat map
+	MaxAdjacentSeats *uint32 `json:"max_adjacent_seats,omitempty"`
 }
 
 type garudaLocation struct {
@@ -70,6 +76,13 @@ type garudaSegment struct {
 }
 
 func (a *GarudaClient) SearchFlights(ctx context.Context, req flight.SearchRequest) (*garudaFlightResponse, error) {
+	if err := a.opts.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("garuda: %w", err)
+	}
+
+	ctx, span := startProviderSpan(ctx, "garuda", req)
+	defer span.End()
+
 	url := fmt.Sprintf("%s/garuda/v1/flights/search", a.baseURL)
 
 	reqBody, err := json.Marshal(req)
@@ -77,7 +90,7 @@ func (a *GarudaClient) SearchFlights(ctx context.Context, req flight.SearchReque
 		return nil, fmt.Errorf("garuda: failed to marshal request: %w", err)
 	}
 
-	r, err := http.NewRequest(http.MethodGet, url, bytes.NewBuffer(reqBody))
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("garuda: failed to build request: %w", err)
 	}
@@ -93,17 +106,42 @@ func (a *GarudaClient) SearchFlights(ctx context.Context, req flight.SearchReque
 	}
 
 	var apiResp garudaFlightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decodeResponse(resp.Body, &apiResp, a.opts); err != nil {
 		return nil, fmt.Errorf("garuda: failed to decode garuda response: %w", err)
 	}
 
 	return &apiResp, nil
 }
 
-func (f *FlightManager) mapGarudaFlights(resp *garudaFlightResponse) []flight.Flight {
+// validateGarudaFlight checks the fields the mapping can't do without
+func validateGarudaFlight(f garudaFlight) error {
+	switch {
+	case f.FlightID == "":
+		return errors.New("missing flight_id")
+	case f.Departure.Time.IsZero():
+		return errors.New("missing departure.time")
+	case f.Arrival.Time.IsZero():
+		return errors.New("missing arrival.time")
+	case f.Price.Amount == 0:
+		return errors.New("missing price.amount")
+	}
+	return nil
+}
+
+// mapGarudaFlights maps valid records to domain flights and returns how many invalid records were skipped
+func (f *FlightManager) mapGarudaFlights(resp *garudaFlightResponse) ([]flight.Flight, uint32) {
 	mapped := make([]flight.Flight, 0, len(resp.Flights))
+	skipped := uint32(0)
 
 	for _, gFlight := range resp.Flights {
+		if err := validateGarudaFlight(gFlight); err != nil {
+			f.logger.Warn("skipping invalid garuda flight",
+				logger.Field{Key: "flight_id", Value: gFlight.FlightID},
+				logger.Field{Key: "err", Value: err.Error()})
+			skipped++
+			continue
+		}
+
 		hours := gFlight.DurationMinutes / 60
 		minutes := gFlight.DurationMinutes % 60
 		formattedDuration := fmt.Sprintf("%dh %dm", hours, minutes)
@@ -118,8 +156,10 @@ func (f *FlightManager) mapGarudaFlights(resp *garudaFlightResponse) []flight.Fl
 		baggageChecked := fmt.Sprintf("Checked: %d", gFlight.Baggage.Checked)
 
 		domainFlight := flight.Flight{
-			ID:       gFlight.FlightID + "_" + "GarudaIndonesia",
-			Provider: gFlight.Airline,
+			ID:               gFlight.FlightID + "_" + "GarudaIndonesia",
+			OfferToken:       gFlight.OfferToken,
+			MaxSeatsTogether: gFlight.MaxAdjacentSeats,
+			Provider:         gFlight.Airline,
 			Airline: flight.Airline{
 				Name: gFlight.Airline,
 				Code: gFlight.AirlineCode,
@@ -157,5 +197,5 @@ func (f *FlightManager) mapGarudaFlights(resp *garudaFlightResponse) []flight.Fl
 		}
 		mapped = append(mapped, domainFlight)
 	}
-	return mapped
+	return mapped, skipped
 }
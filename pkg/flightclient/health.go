@@ -0,0 +1,86 @@
+package flightclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"travel/internal/flight"
+)
+
+// providersHealthTimeout bounds a whole providers health round, slow providers are reported unhealthy
+const providersHealthTimeout = 2 * time.Second
+
+// checkHealth calls a provider health endpoint and reports healthy on a 200.
+// It skips the rate limiter, a health probe must not eat into the search budget.
+func checkHealth(ctx context.Context, httpClient *http.Client, name, url string) flight.ProviderHealth {
+	start := time.Now()
+	health := flight.ProviderHealth{Name: name, Status: flight.ProviderStatusUnhealthy}
+
+	err := func() error {
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build request: %w", err)
+		}
+		resp, err := httpClient.Do(r)
+		if err != nil {
+			return fmt.Errorf("health call failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("health returned non-200 status: %d", resp.StatusCode)
+		}
+		return nil
+	}()
+
+	health.LatencyMs = uint32(time.Since(start).Milliseconds())
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	health.Status = flight.ProviderStatusHealthy
+	return health
+}
+
+func (a *AirAsiaClient) HealthCheck(ctx context.Context) flight.ProviderHealth {
+	return checkHealth(ctx, a.httpClient, ProviderAirAsia, a.baseURL+"/airasia/health")
+}
+
+func (a *BatikAirClient) HealthCheck(ctx context.Context) flight.ProviderHealth {
+	return checkHealth(ctx, a.httpClient, ProviderBatikAir, a.baseURL+"/batikair/health")
+}
+
+func (a *GarudaClient) HealthCheck(ctx context.Context) flight.ProviderHealth {
+	return checkHealth(ctx, a.httpClient, ProviderGaruda, a.baseURL+"/garuda/health")
+}
+
+func (a *LionAirClient) HealthCheck(ctx context.Context) flight.ProviderHealth {
+	return checkHealth(ctx, a.httpClient, ProviderLionAir, a.baseURL+"/lionair/health")
+}
+
+// ProvidersHealth checks every provider in parallel, results keep the provider order
+func (f *FlightManager) ProvidersHealth(ctx context.Context) []flight.ProviderHealth {
+	ctx, cancel := context.WithTimeout(ctx, providersHealthTimeout)
+	defer cancel()
+
+	checks := []func(context.Context) flight.ProviderHealth{
+		f.airAsiaClient.HealthCheck,
+		f.batikAirClient.HealthCheck,
+		f.garudaClient.HealthCheck,
+		f.lionAirClient.HealthCheck,
+	}
+
+	results := make([]flight.ProviderHealth, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = check(ctx)
+		}()
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,80 @@
+package flightclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"travel/internal/flight"
+	"travel/pkg/logger"
+)
+
+func TestProvidersHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/airasia/health", "/garuda/health":
+			w.WriteHeader(http.StatusOK)
+		case "/batikair/health":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	log := logger.NewWithWriter("development", &bytes.Buffer{})
+
+	manager := NewFlightClient(
+		NewAirAsiaClient(srv.Client(), srv.URL, log),
+		NewBatikAirClient(srv.Client(), srv.URL, log),
+		NewGarudaClient(srv.Client(), srv.URL, log),
+		NewLionAirClient(srv.Client(), "http://127.0.0.1:0", log), // nothing listens here
+		log,
+	)
+
+	got := manager.ProvidersHealth(context.Background())
+
+	want := []struct {
+		name   string
+		status string
+	}{
+		{ProviderAirAsia, flight.ProviderStatusHealthy},
+		{ProviderBatikAir, flight.ProviderStatusUnhealthy},
+		{ProviderGaruda, flight.ProviderStatusHealthy},
+		{ProviderLionAir, flight.ProviderStatusUnhealthy},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d providers, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || got[i].Status != w.status {
+			t.Errorf("provider %d: expected %s %s, got %s %s", i, w.name, w.status, got[i].Name, got[i].Status)
+		}
+		if (w.status == flight.ProviderStatusUnhealthy) != (got[i].Error != "") {
+			t.Errorf("%s: expected an error only when unhealthy, got %q", w.name, got[i].Error)
+		}
+	}
+}
+
+func TestCheckHealth_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	got := checkHealth(ctx, srv.Client(), ProviderAirAsia, srv.URL+"/airasia/health")
+	if got.Status != flight.ProviderStatusUnhealthy {
+		t.Errorf("expected a slow provider to be unhealthy, got %s", got.Status)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the check to give up with the context, took %v", elapsed)
+	}
+	if got.LatencyMs < 50 {
+		t.Errorf("expected latency to cover the wait, got %dms", got.LatencyMs)
+	}
+}
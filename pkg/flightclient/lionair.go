@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -16,13 +17,15 @@ type LionAirClient struct {
 	httpClient *http.Client
 	baseURL    string
 	logger     logger.Client
+	opts       clientOptions
 }
 
-func NewLionAirClient(httpClient *http.Client, baseURL string, logger logger.Client) *LionAirClient {
+func NewLionAirClient(httpClient *http.Client, baseURL string, logger logger.Client, opts ...ClientOption) *LionAirClient {
 	return &LionAirClient{
 		httpClient: httpClient,
 		baseURL:    baseURL,
 		logger:     logger,
+		opts:       newClientOptions(opts),
 	}
 }
 
@@ -85,6 +88,8 @@ type LionAirFlight struct {
 	SeatsLeft  uint32           `json:"seats_left"`
 	PlaneType  string           `json:"plane_type"`
 	Services   lionAirServices  `json:"services"`
+	// BookingToken is only sent for fares that can be booked directly
+	BookingToken string `json:"booking_token"`
 }
 
 type lionAirLocation struct {
@@ -94,6 +99,13 @@ type lionAirLocation struct {
 }
 
 func (a *LionAirClient) SearchFlights(ctx context.Context, req flight.SearchRequest) (*LionAirFlightResponse, error) {
+	if err := a.opts.limiter.wait(ctx); err != nil {
+		return nil, fmt.Errorf("lionair: %w", err)
+	}
+
+	ctx, span := startProviderSpan(ctx, "lionair", req)
+	defer span.End()
+
 	url := fmt.Sprintf("%s/lionair/v1/flights/search", a.baseURL)
 
 	reqBody, err := json.Marshal(req)
@@ -101,7 +113,7 @@ func (a *LionAirClient) SearchFlights(ctx context.Context, req flight.SearchRequ
 		return nil, fmt.Errorf("lionair: failed to marshal request: %w", err)
 	}
 
-	r, err := http.NewRequest(http.MethodGet, url, bytes.NewBuffer(reqBody))
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("lionair: failed to build request: %w", err)
 	}
@@ -117,24 +129,49 @@ func (a *LionAirClient) SearchFlights(ctx context.Context, req flight.SearchRequ
 	}
 
 	var apiResp LionAirFlightResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+	if err := decodeResponse(resp.Body, &apiResp, a.opts); err != nil {
 		return nil, fmt.Errorf("lionair: failed to decode lionair response: %w", err)
 	}
 
 	return &apiResp, nil
 }
 
-func (f *FlightManager) mapLionAirFlights(resp *LionAirFlightResponse) ([]flight.Flight, error) {
+// validateLionAirFlight checks the fields the mapping can't do without
+func validateLionAirFlight(f LionAirFlight) error {
+	switch {
+	case f.ID == "":
+		return errors.New("missing id")
+	case f.Schedule.Departure.IsZero():
+		return errors.New("missing schedule.departure")
+	case f.Schedule.Arrival.IsZero():
+		return errors.New("missing schedule.arrival")
+	case f.Pricing.Total == 0:
+		return errors.New("missing pricing.total")
+	}
+	return nil
+}
+
+// mapLionAirFlights maps valid records to domain flights and returns how many invalid records were skipped
+func (f *FlightManager) mapLionAirFlights(resp *LionAirFlightResponse) ([]flight.Flight, uint32, error) {
 	mapped := make([]flight.Flight, 0, len(resp.Data.AvailableFlights))
+	skipped := uint32(0)
 
 	for _, lFlight := range resp.Data.AvailableFlights {
+		if err := validateLionAirFlight(lFlight); err != nil {
+			f.logger.Warn("skipping invalid lion air flight",
+				logger.Field{Key: "flight_id", Value: lFlight.ID},
+				logger.Field{Key: "err", Value: err.Error()})
+			skipped++
+			continue
+		}
+
 		departureTime, err := f.applyTimezone(lFlight.Schedule.Departure.Time, lFlight.Schedule.DepartureTimezone)
 		if err != nil {
 			f.logger.Error("failed to apply departure timezone for lion air flight",
 				logger.Field{Key: "flight_id", Value: lFlight.ID},
 				logger.Field{Key: "timezone", Value: lFlight.Schedule.DepartureTimezone},
 				logger.Field{Key: "err", Value: err})
-			return nil, fmt.Errorf("lionair: failed to apply departure timezone: %w", err)
+			return nil, 0, fmt.Errorf("lionair: failed to apply departure timezone: %w", err)
 		}
 
 		arrivalTime, err := f.applyTimezone(lFlight.Schedule.Arrival.Time, lFlight.Schedule.ArrivalTimezone)
@@ -143,7 +180,7 @@ func (f *FlightManager) mapLionAirFlights(resp *LionAirFlightResponse) ([]flight
 				logger.Field{Key: "flight_id", Value: lFlight.ID},
 				logger.Field{Key: "timezone", Value: lFlight.Schedule.ArrivalTimezone},
 				logger.Field{Key: "err", Value: err})
-			return nil, fmt.Errorf("lionair: failed to apply arrival timezone: %w", err)
+			return nil, 0, fmt.Errorf("lionair: failed to apply arrival timezone: %w", err)
 		}
 
 		totalMinutes := lFlight.FlightTime
@@ -165,8 +202,9 @@ func (f *FlightManager) mapLionAirFlights(resp *LionAirFlightResponse) ([]flight
 		}
 
 		domainFlight := flight.Flight{
-			ID:       lFlight.ID + "_" + lFlight.Carrier.Name,
-			Provider: lFlight.Carrier.Name,
+			ID:         lFlight.ID + "_" + lFlight.Carrier.Name,
+			OfferToken: lFlight.BookingToken,
+			Provider:   lFlight.Carrier.Name,
 			Airline: flight.Airline{
 				Name: lFlight.Carrier.Name,
 				Code: lFlight.Carrier.IATA,
@@ -204,7 +242,7 @@ func (f *FlightManager) mapLionAirFlights(resp *LionAirFlightResponse) ([]flight
 		}
 		mapped = append(mapped, domainFlight)
 	}
-	return mapped, nil
+	return mapped, skipped, nil
 }
 
 // applyTimezone applies a timezone to a time.Time that was parsed without timezone info
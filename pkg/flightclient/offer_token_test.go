@@ -0,0 +1,90 @@
+package flightclient
+
+import (
+	"bytes"
+	"testing"
+	"travel/internal/flight"
+)
+
+func TestMapFlights_PreservesOfferToken(t *testing.T) {
+	m := newTestFlightManager(&bytes.Buffer{})
+
+	tests := []struct {
+		name string
+		raw  string
+		mapf func(t *testing.T, raw string) []flight.Flight
+		want []string
+	}{
+		{
+			name: "AirAsia",
+			raw: `{"flights": [
+				{"flight_code": "QZ520", "airline": "AirAsia", "depart_time": "2025-12-15T04:45:00+07:00", "arrive_time": "2025-12-15T07:25:00+08:00", "price_idr": 650000, "offer_id": "AK-OFF-7f3a9c"},
+				{"flight_code": "QZ524", "airline": "AirAsia", "depart_time": "2025-12-15T10:00:00+07:00", "arrive_time": "2025-12-15T12:45:00+08:00", "price_idr": 720000}
+			]}`,
+			mapf: func(t *testing.T, raw string) []flight.Flight {
+				var resp airAsiaFlightResponse
+				decodeFixture(t, raw, &resp)
+				flights, _ := m.mapAirAsiaFlights(&resp)
+				return flights
+			},
+			want: []string{"AK-OFF-7f3a9c", ""},
+		},
+		{
+			name: "Batik Air",
+			raw: `{"results": [
+				{"flightNumber": "ID6514", "departureDateTime": "2025-12-15T07:15:00+0700", "arrivalDateTime": "2025-12-15T10:00:00+0800", "travelTime": "1h 45m", "fare": {"totalPrice": 1100000}, "fareToken": "eyJmYXJlIjoiWSJ9"}
+			]}`,
+			mapf: func(t *testing.T, raw string) []flight.Flight {
+				var resp batikAirFlightResponse
+				decodeFixture(t, raw, &resp)
+				flights, _ := m.mapBatikFlights(&resp)
+				return flights
+			},
+			want: []string{"eyJmYXJlIjoiWSJ9"},
+		},
+		{
+			name: "Garuda Indonesia",
+			raw: `{"flights": [
+				{"flight_id": "GA400", "departure": {"time": "2025-12-15T06:00:00+07:00"}, "arrival": {"time": "2025-12-15T08:50:00+08:00"}, "price": {"amount": 1250000}, "offer_token": "GA|CGKDPS|Y|1250000"}
+			]}`,
+			mapf: func(t *testing.T, raw string) []flight.Flight {
+				var resp garudaFlightResponse
+				decodeFixture(t, raw, &resp)
+				flights, _ := m.mapGarudaFlights(&resp)
+				return flights
+			},
+			want: []string{"GA|CGKDPS|Y|1250000"},
+		},
+		{
+			name: "Lion Air without token",
+			raw: `{"data": {"available_flights": [
+				{"id": "JT740", "schedule": {"departure": "2025-12-15T05:30:00", "departure_timezone": "Asia/Jakarta", "arrival": "2025-12-15T08:15:00", "arrival_timezone": "Asia/Makassar"}, "pricing": {"total": 950000}},
+				{"id": "JT742", "schedule": {"departure": "2025-12-15T07:30:00", "departure_timezone": "Asia/Jakarta", "arrival": "2025-12-15T10:15:00", "arrival_timezone": "Asia/Makassar"}, "pricing": {"total": 990000}, "booking_token": "JT-BK-001"}
+			]}}`,
+			mapf: func(t *testing.T, raw string) []flight.Flight {
+				var resp LionAirFlightResponse
+				decodeFixture(t, raw, &resp)
+				flights, _, err := m.mapLionAirFlights(&resp)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return flights
+			},
+			want: []string{"", "JT-BK-001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flights := tt.mapf(t, tt.raw)
+			if len(flights) != len(tt.want) {
+				t.Fatalf("expected %d flights, got %d", len(tt.want), len(flights))
+			}
+			for i, want := range tt.want {
+				if flights[i].OfferToken != want {
+					t.Errorf("flight %s: expected offer token %q, got %q", flights[i].FlightNumber, want, flights[i].OfferToken)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package flightclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"travel/internal/flight"
+	"travel/pkg/synthetic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "travel/pkg/flightclient"
+
+// OTelTransport injects the W3C trace context of each request's context (traceparent, tracestate)
+// into the outbound headers, and marks the active span failed when the provider call fails.
+type OTelTransport struct {
+	base http.RoundTripper
+}
+
+// NewOTelTransport wraps base, http.DefaultTransport when nil
+func NewOTelTransport(base http.RoundTripper) *OTelTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &OTelTransport{base: base}
+}
+
+func (t *OTelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request
+	req = req.Clone(req.Context())
+	otel.GetTextMapPropagator().Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	span := trace.SpanFromContext(req.Context())
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, "provider returned "+strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// startProviderSpan starts the "flight.provider.<name>.search" span for one provider call.
+// Spans only record when a TracerProvider is registered, the trace context is propagated either way.
+func startProviderSpan(ctx context.Context, name string, req flight.SearchRequest) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("flight.origin", req.Origin),
+		attribute.String("flight.destination", req.Destination),
+		attribute.String("flight.departure_date", req.DepartureDate),
+	}
+	if synthetic.FromContext(ctx) {
+		attrs = append(attrs, attribute.Bool(synthetic.LogKey, true))
+	}
+	return otel.Tracer(tracerName).Start(ctx, "flight.provider."+name+".search",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
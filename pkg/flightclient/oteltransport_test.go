@@ -0,0 +1,115 @@
+package flightclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"travel/internal/flight"
+	"travel/pkg/logger"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func withTraceContextPropagator(t *testing.T) {
+	t.Helper()
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(prev) })
+}
+
+func parentContext(t *testing.T) (context.Context, trace.TraceID) {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), sc), traceID
+}
+
+func TestOTelTransport_InjectsTraceparent(t *testing.T) {
+	withTraceContextPropagator(t)
+
+	var mu sync.Mutex
+	traceparents := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents[r.URL.Path] = r.Header.Get("traceparent")
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	httpClient := &http.Client{Transport: NewOTelTransport(srv.Client().Transport)}
+	log := logger.NewWithWriter("development", &bytes.Buffer{})
+	manager := NewFlightClient(
+		NewAirAsiaClient(httpClient, srv.URL, log),
+		NewBatikAirClient(httpClient, srv.URL, log),
+		NewGarudaClient(httpClient, srv.URL, log),
+		NewLionAirClient(httpClient, srv.URL, log),
+		log,
+	)
+
+	ctx, traceID := parentContext(t)
+	req := flight.SearchRequest{Origin: "CGK", Destination: "DPS", DepartureDate: "2025-12-15", Passengers: 1}
+	if _, err := manager.SearchFlights(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(traceparents) != 4 {
+		t.Fatalf("expected a call to every provider, got %v", traceparents)
+	}
+	for path, tp := range traceparents {
+		// traceparent is "00-<trace id>-<span id>-<flags>"
+		if !strings.HasPrefix(tp, "00-"+traceID.String()+"-") {
+			t.Errorf("%s: expected traceparent for trace %s, got %q", path, traceID, tp)
+		}
+	}
+}
+
+func TestOTelTransport_LeavesCallerRequestUntouched(t *testing.T) {
+	withTraceContextPropagator(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") == "" {
+			t.Error("expected traceparent on the outbound request")
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, _ := parentContext(t)
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err := NewOTelTransport(srv.Client().Transport).RoundTrip(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := r.Header.Get("traceparent"); got != "" {
+		t.Errorf("expected the caller's request headers to stay untouched, got traceparent %q", got)
+	}
+}
+
+func TestOTelTransport_NoParentNoHeader(t *testing.T) {
+	withTraceContextPropagator(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tp := r.Header.Get("traceparent"); tp != "" {
+			t.Errorf("expected no traceparent without a trace, got %q", tp)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	r, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := NewOTelTransport(srv.Client().Transport).RoundTrip(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+}
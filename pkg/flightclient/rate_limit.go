@@ -0,0 +1,77 @@
+package flightclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned instead of calling a provider when its rate limit is used up
+// and the client is configured to fail fast
+var ErrRateLimited = errors.New("provider rate limit exceeded")
+
+// tokenBucket caps the outbound request rate to one provider.
+// Tokens refill continuously at rate per second, up to burst.
+type tokenBucket struct {
+	rate     float64
+	burst    float64
+	failFast bool
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(qps float64, burst int, failFast bool) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:     qps,
+		burst:    float64(burst),
+		failFast: failFast,
+		now:      time.Now,
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait takes a token, blocking until one is free unless the bucket fails fast.
+// A nil bucket means no limit. Waiting stops when ctx is done, and the token is handed back.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := b.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens < 1 && b.failFast {
+		b.mu.Unlock()
+		return ErrRateLimited
+	}
+	// Reserve the token now, a negative balance is the queue of callers waiting for one
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
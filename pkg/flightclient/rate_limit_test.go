@@ -0,0 +1,104 @@
+package flightclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"travel/internal/flight"
+	"travel/pkg/logger"
+)
+
+func TestTokenBucket_NilIsUnlimited(t *testing.T) {
+	var b *tokenBucket
+	if err := b.wait(context.Background()); err != nil {
+		t.Errorf("expected nil bucket to never block, got %v", err)
+	}
+}
+
+func TestTokenBucket_FailFast(t *testing.T) {
+	now := time.Date(2025, 12, 15, 6, 0, 0, 0, time.UTC)
+	b := newTokenBucket(2, 2, true)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	for i := 0; i < 2; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatalf("call %d: expected burst to be allowed, got %v", i, err)
+		}
+	}
+	if err := b.wait(context.Background()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited once the burst is used, got %v", err)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if err := b.wait(context.Background()); err != nil {
+		t.Errorf("expected a token to refill after 1/qps, got %v", err)
+	}
+}
+
+func TestTokenBucket_WaitRespectsContext(t *testing.T) {
+	b := newTokenBucket(1, 1, false)
+	_ = b.wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected wait to stop at the deadline, got %v", err)
+	}
+
+	b.mu.Lock()
+	tokens := b.tokens
+	b.mu.Unlock()
+	if tokens < -0.1 {
+		t.Errorf("expected the cancelled reservation to be handed back, tokens=%v", tokens)
+	}
+}
+
+func TestAirAsiaClient_RateLimitCapsOutboundRate(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	const qps, requests = 50, 6
+	client := NewAirAsiaClient(srv.Client(), srv.URL, logger.NewWithWriter("development", &bytes.Buffer{}),
+		WithRateLimit(qps, 1, false))
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.SearchFlights(context.Background(), flight.SearchRequest{}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	// One call goes out immediately, the other five are spaced 1/qps apart
+	minElapsed := time.Duration(requests-1) * time.Second / qps
+	if elapsed < minElapsed {
+		t.Errorf("expected %d calls to take at least %v at %d qps, took %v", requests, minElapsed, qps, elapsed)
+	}
+	if got := calls.Load(); got != requests {
+		t.Errorf("expected every call to reach the provider eventually, got %d", got)
+	}
+}
+
+func TestCategorizeError_RateLimited(t *testing.T) {
+	err := categorizeError(ProviderGaruda, ErrRateLimited)
+	if err.Code() != flight.ErrorCodeRateLimited {
+		t.Errorf("expected %s, got %s", flight.ErrorCodeRateLimited, err.Code())
+	}
+}
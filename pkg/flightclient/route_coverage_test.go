@@ -0,0 +1,89 @@
+package flightclient
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"travel/internal/flight"
+	"travel/pkg/logger"
+)
+
+func TestRouteCoverage_Serves(t *testing.T) {
+	tests := []struct {
+		name        string
+		coverage    RouteCoverage
+		origin      string
+		destination string
+		want        bool
+	}{
+		{name: "no coverage serves everything", coverage: RouteCoverage{}, origin: "JFK", destination: "LAX", want: true},
+		{name: "both airports served", coverage: RouteCoverage{Airports: []string{"CGK", "DPS"}}, origin: "CGK", destination: "DPS", want: true},
+		{name: "case insensitive", coverage: RouteCoverage{Airports: []string{"cgk", "dps"}}, origin: "CGK", destination: "DPS", want: true},
+		{name: "destination not served", coverage: RouteCoverage{Airports: []string{"CGK", "DPS"}}, origin: "CGK", destination: "LAX", want: false},
+		{name: "origin not served", coverage: RouteCoverage{Airports: []string{"CGK", "DPS"}}, origin: "JFK", destination: "DPS", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.coverage.serves(tt.origin, tt.destination); got != tt.want {
+				t.Errorf("serves(%s, %s) = %v, want %v", tt.origin, tt.destination, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchFlights_SkipsProvidersOutsideCoverage(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	log := logger.NewWithWriter("development", &bytes.Buffer{})
+
+	indonesia := RouteCoverage{Airports: []string{"CGK", "DPS", "SUB"}}
+	manager := NewFlightClient(
+		NewAirAsiaClient(srv.Client(), srv.URL, log),
+		NewBatikAirClient(srv.Client(), srv.URL, log),
+		NewGarudaClient(srv.Client(), srv.URL, log),
+		NewLionAirClient(srv.Client(), srv.URL, log),
+		log,
+		WithRouteCoverage(ProviderGaruda, indonesia),
+		WithRouteCoverage(ProviderLionAir, indonesia),
+	)
+
+	tests := []struct {
+		name        string
+		destination string
+		wantQueried uint32
+		wantSkipped uint32
+	}{
+		{name: "domestic route queries everyone", destination: "DPS", wantQueried: 4, wantSkipped: 0},
+		{name: "route outside coverage skips providers", destination: "SIN", wantQueried: 2, wantSkipped: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+			req := flight.SearchRequest{Origin: "CGK", Destination: tt.destination, DepartureDate: "2025-12-15", Passengers: 1}
+
+			resp, err := manager.SearchFlights(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Metadata.ProvidersQueried != tt.wantQueried || resp.Metadata.ProvidersSkipped != tt.wantSkipped {
+				t.Errorf("expected %d queried and %d skipped, got %d and %d", tt.wantQueried, tt.wantSkipped,
+					resp.Metadata.ProvidersQueried, resp.Metadata.ProvidersSkipped)
+			}
+			if resp.Metadata.ProvidersFailed != 0 {
+				t.Errorf("skipped providers must not count as failed, got %d", resp.Metadata.ProvidersFailed)
+			}
+			if got := calls.Load(); got != int32(tt.wantQueried) {
+				t.Errorf("expected %d provider calls, got %d", tt.wantQueried, got)
+			}
+		})
+	}
+}
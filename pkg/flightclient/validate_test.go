@@ -0,0 +1,91 @@
+package flightclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"travel/pkg/logger"
+)
+
+func newTestFlightManager(buf *bytes.Buffer) *FlightManager {
+	return &FlightManager{logger: logger.NewWithWriter("development", buf)}
+}
+
+func decodeFixture(t *testing.T, raw string, v any) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(raw), v); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+}
+
+func TestMapAirAsiaFlights_SkipsMalformed(t *testing.T) {
+	var resp airAsiaFlightResponse
+	decodeFixture(t, `{"flights": [
+		{"flight_code": "QZ520", "airline": "AirAsia", "depart_time": "2025-12-15T04:45:00+07:00", "arrive_time": "2025-12-15T07:25:00+08:00", "direct_flight": true, "price_idr": 650000},
+		{"flight_code": "", "airline": "AirAsia", "depart_time": "2025-12-15T04:45:00+07:00", "arrive_time": "2025-12-15T07:25:00+08:00", "price_idr": 650000},
+		{"flight_code": "Q", "airline": "AirAsia", "depart_time": "2025-12-15T04:45:00+07:00", "arrive_time": "2025-12-15T07:25:00+08:00", "price_idr": 650000},
+		{"flight_code": "QZ524", "airline": "AirAsia", "arrive_time": "2025-12-15T07:25:00+08:00", "price_idr": 650000},
+		{"flight_code": "QZ526", "airline": "AirAsia", "depart_time": "2025-12-15T04:45:00+07:00", "arrive_time": "2025-12-15T07:25:00+08:00"}
+	]}`, &resp)
+
+	buf := &bytes.Buffer{}
+	flights, skipped := newTestFlightManager(buf).mapAirAsiaFlights(&resp)
+
+	if len(flights) != 1 || flights[0].FlightNumber != "QZ520" {
+		t.Errorf("expected only QZ520 to be mapped, got %+v", flights)
+	}
+	if skipped != 4 {
+		t.Errorf("expected 4 skipped flights, got %d", skipped)
+	}
+	if !strings.Contains(buf.String(), `"flight_id":"QZ524"`) {
+		t.Errorf("expected skipped flight id in warn log, got: %s", buf.String())
+	}
+}
+
+func TestMapBatikFlights_SkipsMalformed(t *testing.T) {
+	var resp batikAirFlightResponse
+	decodeFixture(t, `{"results": [
+		{"flightNumber": "ID6514", "departureDateTime": "2025-12-15T07:15:00+0700", "arrivalDateTime": "2025-12-15T10:00:00+0800", "travelTime": "1h 45m", "fare": {"totalPrice": 1100000}},
+		{"flightNumber": "ID6520", "arrivalDateTime": "2025-12-15T10:00:00+0800", "fare": {"totalPrice": 1100000}},
+		{"flightNumber": "ID6522", "departureDateTime": "2025-12-15T07:15:00+0700", "arrivalDateTime": "2025-12-15T10:00:00+0800", "fare": {}}
+	]}`, &resp)
+
+	flights, skipped := newTestFlightManager(&bytes.Buffer{}).mapBatikFlights(&resp)
+
+	if len(flights) != 1 || skipped != 2 {
+		t.Errorf("expected 1 mapped and 2 skipped, got %d mapped and %d skipped", len(flights), skipped)
+	}
+}
+
+func TestMapGarudaFlights_SkipsMalformed(t *testing.T) {
+	var resp garudaFlightResponse
+	decodeFixture(t, `{"flights": [
+		{"flight_id": "GA400", "departure": {"time": "2025-12-15T06:00:00+07:00"}, "arrival": {"time": "2025-12-15T08:50:00+08:00"}, "price": {"amount": 1250000}},
+		{"flight_id": "", "departure": {"time": "2025-12-15T06:00:00+07:00"}, "arrival": {"time": "2025-12-15T08:50:00+08:00"}, "price": {"amount": 1250000}},
+		{"flight_id": "GA410", "departure": {"time": "2025-12-15T06:00:00+07:00"}, "arrival": {"time": null}, "price": {"amount": 1250000}}
+	]}`, &resp)
+
+	flights, skipped := newTestFlightManager(&bytes.Buffer{}).mapGarudaFlights(&resp)
+
+	if len(flights) != 1 || skipped != 2 {
+		t.Errorf("expected 1 mapped and 2 skipped, got %d mapped and %d skipped", len(flights), skipped)
+	}
+}
+
+func TestMapLionAirFlights_SkipsMalformed(t *testing.T) {
+	var resp LionAirFlightResponse
+	decodeFixture(t, `{"data": {"available_flights": [
+		{"id": "JT740", "schedule": {"departure": "2025-12-15T05:30:00", "departure_timezone": "Asia/Jakarta", "arrival": "2025-12-15T08:15:00", "arrival_timezone": "Asia/Makassar"}, "pricing": {"total": 950000}},
+		{"id": "JT742", "schedule": {"departure": "2025-12-15T05:30:00", "departure_timezone": "Asia/Jakarta", "arrival_timezone": "Asia/Makassar"}, "pricing": {"total": 950000}},
+		{"id": "JT744", "schedule": {"departure": "2025-12-15T05:30:00", "departure_timezone": "Asia/Jakarta", "arrival": "2025-12-15T08:15:00", "arrival_timezone": "Asia/Makassar"}, "pricing": {}}
+	]}}`, &resp)
+
+	flights, skipped, err := newTestFlightManager(&bytes.Buffer{}).mapLionAirFlights(&resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(flights) != 1 || skipped != 2 {
+		t.Errorf("expected 1 mapped and 2 skipped, got %d mapped and %d skipped", len(flights), skipped)
+	}
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncZeroLogger is a ZeroLogger whose writes go through a fixed-size buffer
+// drained by a background goroutine, so logging never waits on a slow stdout.
+// When the buffer is full the entry is written synchronously instead and counted in DroppedLogs.
+// Call Close before the process exits, otherwise buffered entries are lost.
+type AsyncZeroLogger struct {
+	*ZeroLogger
+	writer *asyncWriter
+}
+
+func NewAsyncZeroLog(env string, bufferSize int, opts ...LoggerOption) (*AsyncZeroLogger, error) {
+	return NewAsyncWithWriter(env, os.Stdout, bufferSize, opts...)
+}
+
+func NewAsyncWithWriter(env string, w io.Writer, bufferSize int, opts ...LoggerOption) (*AsyncZeroLogger, error) {
+	if bufferSize <= 0 {
+		return nil, errors.New("logger: async buffer size must be positive")
+	}
+	aw := newAsyncWriter(w, bufferSize)
+	return &AsyncZeroLogger{
+		ZeroLogger: NewWithWriter(env, aw, opts...),
+		writer:     aw,
+	}, nil
+}
+
+// DroppedLogs is the number of entries that did not fit in the buffer and were written synchronously
+func (l *AsyncZeroLogger) DroppedLogs() uint64 {
+	return l.writer.dropped.Load()
+}
+
+// Flush blocks until every buffered entry has been written and returns the first write error since the last flush
+func (l *AsyncZeroLogger) Flush() error {
+	return l.writer.flush()
+}
+
+// WithFields returns a child that writes through the same buffer, so Flush and Close on the parent cover it
+func (l *AsyncZeroLogger) WithFields(fields ...Field) Client {
+	return &AsyncZeroLogger{ZeroLogger: l.withFields(fields), writer: l.writer}
+}
+
+// Fatal flushes the buffer before exiting, so the fatal entry and everything before it is written
+func (l *AsyncZeroLogger) Fatal(msg string, fields ...Field) {
+	l.logFatal(msg, fields)
+	_ = l.Flush()
+	l.exitFunc(1)
+}
+
+// Close drains the buffer and stops the background goroutine.
+// Entries logged after Close are written synchronously.
+func (l *AsyncZeroLogger) Close() error {
+	return l.writer.close()
+}
+
+// asyncEntry is either a log line or, when done is set, a flush marker
+type asyncEntry struct {
+	line []byte
+	done chan struct{}
+}
+
+type asyncWriter struct {
+	out     io.Writer
+	outMu   sync.Mutex // serializes the drain goroutine and synchronous fallback writes
+	entries chan asyncEntry
+	stopped chan struct{}
+
+	mu     sync.RWMutex // guards closed, so nothing is sent on a closed channel
+	closed bool
+
+	dropped atomic.Uint64
+	errMu   sync.Mutex
+	err     error
+}
+
+func newAsyncWriter(out io.Writer, bufferSize int) *asyncWriter {
+	w := &asyncWriter{
+		out:     out,
+		entries: make(chan asyncEntry, bufferSize),
+		stopped: make(chan struct{}),
+	}
+	go w.drain()
+	return w
+}
+
+// Write is called by zerolog, which reuses p after returning, so the line is copied before queueing
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if !w.closed {
+		line := make([]byte, len(p))
+		copy(line, p)
+		select {
+		case w.entries <- asyncEntry{line: line}:
+			return len(p), nil
+		default:
+			w.dropped.Add(1)
+		}
+	}
+	return w.writeSync(p)
+}
+
+func (w *asyncWriter) writeSync(p []byte) (int, error) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	return w.out.Write(p)
+}
+
+func (w *asyncWriter) drain() {
+	defer close(w.stopped)
+	for entry := range w.entries {
+		if entry.done != nil {
+			close(entry.done)
+			continue
+		}
+		if _, err := w.writeSync(entry.line); err != nil {
+			w.errMu.Lock()
+			if w.err == nil {
+				w.err = err
+			}
+			w.errMu.Unlock()
+		}
+	}
+}
+
+func (w *asyncWriter) takeErr() error {
+	w.errMu.Lock()
+	defer w.errMu.Unlock()
+	err := w.err
+	w.err = nil
+	return err
+}
+
+func (w *asyncWriter) flush() error {
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		return w.takeErr()
+	}
+	done := make(chan struct{})
+	// Blocking send: the marker must queue behind every entry already buffered
+	w.entries <- asyncEntry{done: done}
+	w.mu.RUnlock()
+
+	<-done
+	return w.takeErr()
+}
+
+func (w *asyncWriter) close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	<-w.stopped
+	return w.takeErr()
+}
@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// syncBuffer is a bytes.Buffer safe to write from the drain goroutine while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// blockingWriter signals entered and then holds every write until release is closed
+type blockingWriter struct {
+	syncBuffer
+	entered chan struct{}
+	once    sync.Once
+	release chan struct{}
+}
+
+func (b *blockingWriter) Write(p []byte) (int, error) {
+	b.once.Do(func() { close(b.entered) })
+	<-b.release
+	return b.syncBuffer.Write(p)
+}
+
+func TestNewAsyncZeroLog_InvalidBufferSize(t *testing.T) {
+	if _, err := NewAsyncZeroLog("development", 0); err == nil {
+		t.Errorf("expected error for zero buffer size")
+	}
+}
+
+func TestAsyncZeroLogger_Flush(t *testing.T) {
+	buf := &syncBuffer{}
+	log, err := NewAsyncWithWriter("development", buf, 16)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer log.Close()
+
+	for i := 0; i < 10; i++ {
+		log.Info("async-test", Field{Key: "i", Value: i})
+	}
+	if err := log.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	output := buf.String()
+	if got := strings.Count(output, "async-test"); got != 10 {
+		t.Errorf("expected 10 entries after flush, got %d: %s", got, output)
+	}
+	if !strings.Contains(output, `"i":9`) {
+		t.Errorf("expected last entry intact, got: %s", output)
+	}
+	if log.DroppedLogs() != 0 {
+		t.Errorf("expected no dropped logs, got %d", log.DroppedLogs())
+	}
+}
+
+func TestAsyncZeroLogger_FullBufferFallsBackToSync(t *testing.T) {
+	w := &blockingWriter{entered: make(chan struct{}), release: make(chan struct{})}
+	log, err := NewAsyncWithWriter("development", w, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The drain goroutine takes the first entry and blocks writing it,
+	// the second fills the buffer, the third has to go synchronously.
+	log.Info("first")
+	<-w.entered
+	log.Info("second")
+
+	done := make(chan struct{})
+	go func() {
+		log.Info("overflow")
+		close(done)
+	}()
+	// overflow is counted before its synchronous write blocks on the writer
+	for log.DroppedLogs() == 0 {
+		runtime.Gosched()
+	}
+	close(w.release)
+	<-done
+
+	if err := log.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if log.DroppedLogs() != 1 {
+		t.Errorf("expected exactly one synchronous fallback, got %d", log.DroppedLogs())
+	}
+	if !strings.Contains(w.String(), "overflow") {
+		t.Errorf("expected overflow entry to be written, got: %s", w.String())
+	}
+}
+
+func TestAsyncZeroLogger_CloseDrainsAndAllowsLateWrites(t *testing.T) {
+	buf := &syncBuffer{}
+	log, _ := NewAsyncWithWriter("development", buf, 16)
+
+	log.Warn("before-close")
+	if err := log.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if !strings.Contains(buf.String(), "before-close") {
+		t.Errorf("expected buffered entry written on close, got: %s", buf.String())
+	}
+
+	log.Warn("after-close")
+	if !strings.Contains(buf.String(), "after-close") {
+		t.Errorf("expected entry after close to be written synchronously, got: %s", buf.String())
+	}
+	if err := log.Flush(); err != nil {
+		t.Errorf("flush after close: %v", err)
+	}
+}
+
+func BenchmarkZeroLogger_Sync(b *testing.B) {
+	log := NewWithWriter("production", io.Discard)
+	for b.Loop() {
+		log.Info("search_done", Field{Key: "provider", Value: "Garuda Indonesia"}, Field{Key: "flights", Value: 42})
+	}
+}
+
+func BenchmarkZeroLogger_Async(b *testing.B) {
+	log, _ := NewAsyncWithWriter("production", io.Discard, 4096)
+	defer log.Close()
+	for b.Loop() {
+		log.Info("search_done", Field{Key: "provider", Value: "Garuda Indonesia"}, Field{Key: "flights", Value: 42})
+	}
+}
+
+func TestAsyncZeroLogger_FatalFlushesBeforeExit(t *testing.T) {
+	out := &syncBuffer{}
+	var atExit string
+	log, err := NewAsyncWithWriter("development", out, 16, WithExitFunc(func(int) {
+		atExit = out.String()
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer log.Close()
+
+	log.Info("before-fatal")
+	log.Fatal("fatal-test")
+
+	if !strings.Contains(atExit, "before-fatal") || !strings.Contains(atExit, "fatal-test") {
+		t.Errorf("expected every entry to be written before exit, got: %s", atExit)
+	}
+}
@@ -1,10 +1,25 @@
 package logger
 
+import "errors"
+
+// ErrInvalidLevel is returned by SetLevel for anything but debug, info, warn and error
+var ErrInvalidLevel = errors.New("logger: invalid level, expected debug, info, warn or error")
+
 type Client interface {
 	Debug(msg string, fields ...Field)
 	Info(msg string, fields ...Field)
 	Warn(msg string, fields ...Field)
 	Error(msg string, fields ...Field)
+	// Fatal logs at fatal level and exits the process with status 1
+	Fatal(msg string, fields ...Field)
+	// WithFields returns a child logger that adds fields to every entry, the parent is left untouched
+	WithFields(fields ...Field) Client
+}
+
+// LevelController changes the minimum level at runtime, without a restart
+type LevelController interface {
+	Level() string
+	SetLevel(level string) error
 }
 
 // Field represents a dynamic field in logs
@@ -12,3 +27,11 @@ type Field struct {
 	Key   string
 	Value any
 }
+
+// ErrField attaches err under the "error" key, a nil err is left out of the log
+func ErrField(err error) Field {
+	if err == nil {
+		return Field{}
+	}
+	return Field{Key: "error", Value: err}
+}
@@ -0,0 +1,20 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromContext returns base with the trace_id and span_id of the span in ctx attached,
+// so entries can be matched to the trace. base is returned as is when ctx carries no valid span.
+func FromContext(ctx context.Context, base Client) Client {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return base
+	}
+	return base.WithFields(
+		Field{Key: "trace_id", Value: sc.TraceID().String()},
+		Field{Key: "span_id", Value: sc.SpanID().String()},
+	)
+}
@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFromContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantTrace bool
+	}{
+		{name: "span in context", ctx: trace.ContextWithSpanContext(context.Background(), sc), wantTrace: true},
+		{name: "remote span in context", ctx: trace.ContextWithRemoteSpanContext(context.Background(), sc), wantTrace: true},
+		{name: "no span", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			FromContext(tt.ctx, NewWithWriter("development", buf)).Info("traced")
+
+			output := buf.String()
+			hasTrace := strings.Contains(output, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) &&
+				strings.Contains(output, `"span_id":"00f067aa0ba902b7"`)
+			if hasTrace != tt.wantTrace {
+				t.Errorf("expected trace fields %v, got: %s", tt.wantTrace, output)
+			}
+			if !tt.wantTrace && strings.Contains(output, "trace_id") {
+				t.Errorf("expected no trace_id without a span, got: %s", output)
+			}
+		})
+	}
+}
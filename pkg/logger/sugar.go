@@ -0,0 +1,70 @@
+package logger
+
+import "fmt"
+
+// SugaredLogger mirrors the printf-style and key-value methods of zap's SugaredLogger,
+// so code written against zap can move to this package without rewriting every call
+type SugaredLogger interface {
+	Debugf(template string, args ...any)
+	Infof(template string, args ...any)
+	Warnf(template string, args ...any)
+	Errorf(template string, args ...any)
+
+	Debugw(msg string, keysAndValues ...any)
+	Infow(msg string, keysAndValues ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorw(msg string, keysAndValues ...any)
+}
+
+// ignoredKey holds a trailing value that has no key, the same key zap uses
+const ignoredKey = "ignored"
+
+// SugarLogger adapts a Client to SugaredLogger
+type SugarLogger struct {
+	l Client
+}
+
+var _ SugaredLogger = (*SugarLogger)(nil)
+
+func NewSugarLogger(l Client) *SugarLogger {
+	return &SugarLogger{l: l}
+}
+
+func (s *SugarLogger) Debugf(template string, args ...any) { s.l.Debug(fmt.Sprintf(template, args...)) }
+func (s *SugarLogger) Infof(template string, args ...any)  { s.l.Info(fmt.Sprintf(template, args...)) }
+func (s *SugarLogger) Warnf(template string, args ...any)  { s.l.Warn(fmt.Sprintf(template, args...)) }
+func (s *SugarLogger) Errorf(template string, args ...any) { s.l.Error(fmt.Sprintf(template, args...)) }
+
+func (s *SugarLogger) Debugw(msg string, keysAndValues ...any) {
+	s.l.Debug(msg, sweetenFields(keysAndValues)...)
+}
+
+func (s *SugarLogger) Infow(msg string, keysAndValues ...any) {
+	s.l.Info(msg, sweetenFields(keysAndValues)...)
+}
+
+func (s *SugarLogger) Warnw(msg string, keysAndValues ...any) {
+	s.l.Warn(msg, sweetenFields(keysAndValues)...)
+}
+
+func (s *SugarLogger) Errorw(msg string, keysAndValues ...any) {
+	s.l.Error(msg, sweetenFields(keysAndValues)...)
+}
+
+// sweetenFields turns alternating keys and values into Fields.
+// Keys that aren't strings are formatted with fmt.Sprint, a value without a key goes under "ignored".
+func sweetenFields(keysAndValues []any) []Field {
+	fields := make([]Field, 0, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			fields = append(fields, Field{Key: ignoredKey, Value: keysAndValues[i]})
+			break
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, Field{Key: key, Value: keysAndValues[i+1]})
+	}
+	return fields
+}
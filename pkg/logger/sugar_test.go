@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSugarLogger_Printf(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewSugarLogger(NewWithWriter("development", buf))
+
+	tests := []struct {
+		name      string
+		log       func()
+		wantMsg   string
+		wantLevel string
+	}{
+		{name: "debugf", log: func() { log.Debugf("cache %s", "miss") }, wantMsg: "cache miss", wantLevel: "debug"},
+		{name: "infof expands %v", log: func() { log.Infof("found %v flights", 12) }, wantMsg: "found 12 flights", wantLevel: "info"},
+		{name: "warnf", log: func() { log.Warnf("slow provider %q", "Lion Air") }, wantMsg: `slow provider "Lion Air"`, wantLevel: "warn"},
+		{name: "errorf", log: func() { log.Errorf("fetch failed: %v", errors.New("timeout")) }, wantMsg: "fetch failed: timeout", wantLevel: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.log()
+
+			var entry map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("decode %q: %v", buf.String(), err)
+			}
+			if entry["message"] != tt.wantMsg {
+				t.Errorf("expected message %q, got %v", tt.wantMsg, entry["message"])
+			}
+			if entry["level"] != tt.wantLevel {
+				t.Errorf("expected level %q, got %v", tt.wantLevel, entry["level"])
+			}
+		})
+	}
+}
+
+func TestSugarLogger_KeysAndValues(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewSugarLogger(NewWithWriter("development", buf))
+
+	log.Infow("search done", "provider", "AirAsia", "flights", 3, "cache_hit", false, "email", "a@b.c")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode %q: %v", buf.String(), err)
+	}
+	want := map[string]any{
+		"message":   "search done",
+		"provider":  "AirAsia",
+		"flights":   float64(3),
+		"cache_hit": false,
+		"email":     redactedValue, // the wrapped logger still redacts
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, entry[key])
+		}
+	}
+}
+
+func TestSugarLogger_MalformedPairs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewSugarLogger(NewWithWriter("development", buf))
+
+	log.Warnw("odd pairs", 42, "answer", "dangling")
+
+	output := buf.String()
+	if !strings.Contains(output, `"42":"answer"`) {
+		t.Errorf("expected a non-string key to be formatted, got: %s", output)
+	}
+	if !strings.Contains(output, `"ignored":"dangling"`) {
+		t.Errorf("expected a value without key under ignored, got: %s", output)
+	}
+}
@@ -1,21 +1,51 @@
 package logger
 
 import (
+	"errors"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/rs/zerolog"
 )
 
 type ZeroLogger struct {
 	zlogger zerolog.Logger
+	redact  map[string]struct{}
+	// exitFunc ends the process after Fatal, os.Exit unless replaced with WithExitFunc
+	exitFunc func(int)
 }
 
-func NewZeroLog(env string) *ZeroLogger {
-	return NewWithWriter(env, os.Stdout)
+// LoggerOption configures a ZeroLogger
+type LoggerOption func(*ZeroLogger)
+
+// redactedValue replaces the value of every redacted field
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedKeys are always redacted, Redact adds to them
+var defaultRedactedKeys = []string{"email", "access_token", "refresh_token"}
+
+// Redact replaces the value of fields with these keys (case insensitive) by "[REDACTED]"
+func Redact(keys ...string) LoggerOption {
+	return func(l *ZeroLogger) {
+		for _, key := range keys {
+			l.redact[strings.ToLower(key)] = struct{}{}
+		}
+	}
+}
+
+// WithExitFunc replaces os.Exit as what Fatal calls after logging, so tests can observe it
+func WithExitFunc(exit func(int)) LoggerOption {
+	return func(l *ZeroLogger) {
+		l.exitFunc = exit
+	}
+}
+
+func NewZeroLog(env string, opts ...LoggerOption) *ZeroLogger {
+	return NewWithWriter(env, os.Stdout, opts...)
 }
 
-func NewWithWriter(env string, w io.Writer) *ZeroLogger {
+func NewWithWriter(env string, w io.Writer, opts ...LoggerOption) *ZeroLogger {
 	logger := zerolog.New(w).With().Timestamp().Logger()
 
 	switch env {
@@ -25,12 +55,44 @@ func NewWithWriter(env string, w io.Writer) *ZeroLogger {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	}
 
-	return &ZeroLogger{zlogger: logger}
+	l := &ZeroLogger{zlogger: logger, redact: make(map[string]struct{}), exitFunc: os.Exit}
+	Redact(defaultRedactedKeys...)(l)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Level returns the current global level
+func (l *ZeroLogger) Level() string {
+	return zerolog.GlobalLevel().String()
+}
+
+// SetLevel changes the global level, which applies to every ZeroLogger in the process
+func (l *ZeroLogger) SetLevel(level string) error {
+	switch level {
+	case "debug", "info", "warn", "error":
+	default:
+		return ErrInvalidLevel
+	}
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return ErrInvalidLevel
+	}
+	zerolog.SetGlobalLevel(parsed)
+	return nil
 }
 
 // logWithFields applies dynamic fields efficiently using typed methods
 func (l *ZeroLogger) logWithFields(event *zerolog.Event, fields []Field) *zerolog.Event {
 	for _, f := range fields {
+		if f.Key == "" {
+			continue
+		}
+		if _, ok := l.redact[strings.ToLower(f.Key)]; ok {
+			event.Str(f.Key, redactedValue)
+			continue
+		}
 		switch v := f.Value.(type) {
 		case string:
 			event.Str(f.Key, v)
@@ -42,6 +104,11 @@ func (l *ZeroLogger) logWithFields(event *zerolog.Event, fields []Field) *zerolo
 			event.Float64(f.Key, v)
 		case bool:
 			event.Bool(f.Key, v)
+		case error:
+			event.AnErr(f.Key, v)
+			if chain := errorChain(v); len(chain) > 1 {
+				event.Strs(f.Key+"_chain", chain)
+			}
 		default:
 			event.Interface(f.Key, v) // fallback for complex types
 		}
@@ -49,6 +116,45 @@ func (l *ZeroLogger) logWithFields(event *zerolog.Event, fields []Field) *zerolo
 	return event
 }
 
+// WithFields returns a child logger with fields pre-attached. Redaction applies to them the same
+// way as to per-call fields, and the child shares the parent's writer, redacted keys and exit func.
+func (l *ZeroLogger) WithFields(fields ...Field) Client {
+	return l.withFields(fields)
+}
+
+func (l *ZeroLogger) withFields(fields []Field) *ZeroLogger {
+	kv := make([]any, 0, 2*len(fields))
+	for _, f := range fields {
+		if f.Key == "" {
+			continue
+		}
+		if _, ok := l.redact[strings.ToLower(f.Key)]; ok {
+			kv = append(kv, f.Key, redactedValue)
+			continue
+		}
+		kv = append(kv, f.Key, f.Value)
+		if err, ok := f.Value.(error); ok {
+			if chain := errorChain(err); len(chain) > 1 {
+				kv = append(kv, f.Key+"_chain", chain)
+			}
+		}
+	}
+
+	child := *l
+	child.zlogger = l.zlogger.With().Fields(kv).Logger()
+	return &child
+}
+
+// errorChain lists the messages of err and every error it wraps, outermost first
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		chain = append(chain, err.Error())
+		err = errors.Unwrap(err)
+	}
+	return chain
+}
+
 func (l *ZeroLogger) Debug(msg string, fields ...Field) {
 	l.logWithFields(l.zlogger.Debug(), fields).Msg(msg)
 }
@@ -64,3 +170,13 @@ func (l *ZeroLogger) Warn(msg string, fields ...Field) {
 func (l *ZeroLogger) Error(msg string, fields ...Field) {
 	l.logWithFields(l.zlogger.Error(), fields).Msg(msg)
 }
+
+func (l *ZeroLogger) Fatal(msg string, fields ...Field) {
+	l.logFatal(msg, fields)
+	l.exitFunc(1)
+}
+
+// logFatal writes the fatal entry without exiting, zerolog's own Fatal would call os.Exit itself
+func (l *ZeroLogger) logFatal(msg string, fields []Field) {
+	l.logWithFields(l.zlogger.WithLevel(zerolog.FatalLevel), fields).Msg(msg)
+}
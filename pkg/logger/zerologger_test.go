@@ -2,6 +2,8 @@ package logger
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -77,3 +79,184 @@ func TestZeroLogger_Error(t *testing.T) {
 		t.Errorf("expected error level, got: %s", output)
 	}
 }
+
+func TestZeroLogger_ErrField(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf)
+
+	log.Error("error-test", ErrField(errors.New("connection refused")))
+
+	output := buf.String()
+	if !strings.Contains(output, `"error":"connection refused"`) {
+		t.Errorf("expected error field, got: %s", output)
+	}
+	if strings.Contains(output, "error_chain") {
+		t.Errorf("expected no chain for unwrapped error, got: %s", output)
+	}
+}
+
+func TestZeroLogger_ErrFieldWrapped(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf)
+
+	root := errors.New("connection refused")
+	log.Error("error-test", ErrField(fmt.Errorf("fetch garuda: %w", root)))
+
+	output := buf.String()
+	if !strings.Contains(output, `"error":"fetch garuda: connection refused"`) {
+		t.Errorf("expected wrapped error message, got: %s", output)
+	}
+	if !strings.Contains(output, `"error_chain":["fetch garuda: connection refused","connection refused"]`) {
+		t.Errorf("expected unwrapped chain, got: %s", output)
+	}
+}
+
+func TestZeroLogger_ErrFieldNil(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf)
+
+	log.Info("info-test", ErrField(nil))
+
+	output := buf.String()
+	if strings.Contains(output, "error") {
+		t.Errorf("expected no error field for nil error, got: %s", output)
+	}
+}
+
+func TestZeroLogger_SetLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf)
+	defer func() { _ = log.SetLevel("debug") }()
+
+	if err := log.SetLevel("error"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	log.Warn("warn-hidden")
+	if buf.String() != "" {
+		t.Errorf("expected warn hidden at error level, got: %s", buf.String())
+	}
+	if log.Level() != "error" {
+		t.Errorf("expected level error, got %s", log.Level())
+	}
+
+	if err := log.SetLevel("verbose"); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("expected ErrInvalidLevel, got %v", err)
+	}
+	if log.Level() != "error" {
+		t.Errorf("expected level unchanged after invalid level, got %s", log.Level())
+	}
+}
+
+func TestZeroLogger_Redact(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []LoggerOption
+		field  Field
+		secret string
+	}{
+		{name: "default email", field: Field{Key: "email", Value: "budi@example.com"}, secret: "budi@example.com"},
+		{name: "default access token", field: Field{Key: "access_token", Value: "ya29.a0Af"}, secret: "ya29.a0Af"},
+		{name: "default refresh token", field: Field{Key: "Refresh_Token", Value: "1//0g"}, secret: "1//0g"},
+		{name: "custom key", opts: []LoggerOption{Redact("passport")}, field: Field{Key: "passport", Value: "X1234567"}, secret: "X1234567"},
+		{name: "non string value", opts: []LoggerOption{Redact("card")}, field: Field{Key: "card", Value: 4111111111111111}, secret: "4111111111111111"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			log := NewWithWriter("development", buf, tt.opts...)
+
+			log.Debug("debug-test", tt.field)
+			log.Info("info-test", tt.field)
+			log.Warn("warn-test", tt.field)
+			log.Error("error-test", tt.field)
+
+			output := buf.String()
+			if strings.Contains(output, tt.secret) {
+				t.Errorf("expected %q to be redacted, got: %s", tt.secret, output)
+			}
+			if got := strings.Count(output, `"`+tt.field.Key+`":"[REDACTED]"`); got != 4 {
+				t.Errorf("expected 4 redacted entries, got %d: %s", got, output)
+			}
+		})
+	}
+}
+
+func TestZeroLogger_RedactKeepsOtherFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf)
+
+	log.Info("info-test", Field{Key: "origin", Value: "CGK"})
+
+	if !strings.Contains(buf.String(), `"origin":"CGK"`) {
+		t.Errorf("expected non sensitive field untouched, got: %s", buf.String())
+	}
+}
+
+func TestZeroLogger_Fatal(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var exitCode int
+	exits := 0
+	log := NewWithWriter("development", buf, WithExitFunc(func(code int) {
+		exits++
+		exitCode = code
+	}))
+
+	log.Fatal("fatal-test", Field{Key: "reason", Value: "redis down"})
+
+	output := buf.String()
+	if !strings.Contains(output, "fatal-test") || !strings.Contains(output, `"reason":"redis down"`) {
+		t.Errorf("expected fatal message with fields, got: %s", output)
+	}
+	if !strings.Contains(output, `"level":"fatal"`) {
+		t.Errorf("expected level=fatal, got: %s", output)
+	}
+	if exits != 1 || exitCode != 1 {
+		t.Errorf("expected one exit with status 1, got %d exits with status %d", exits, exitCode)
+	}
+}
+
+func TestZeroLogger_WithFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	parent := NewWithWriter("development", buf)
+	child := parent.WithFields(
+		Field{Key: "origin", Value: "CGK"},
+		Field{Key: "passengers", Value: 2},
+		Field{Key: "access_token", Value: "secret-token"},
+	)
+
+	child.Info("child-entry", Field{Key: "provider", Value: "Garuda Indonesia"})
+	childOutput := buf.String()
+	buf.Reset()
+	parent.Info("parent-entry")
+	parentOutput := buf.String()
+
+	for _, want := range []string{`"origin":"CGK"`, `"passengers":2`, `"provider":"Garuda Indonesia"`, `"access_token":"[REDACTED]"`} {
+		if !strings.Contains(childOutput, want) {
+			t.Errorf("expected %s in child entry, got: %s", want, childOutput)
+		}
+	}
+	if strings.Contains(childOutput, "secret-token") {
+		t.Errorf("expected pre-set fields to be redacted, got: %s", childOutput)
+	}
+	if strings.Contains(parentOutput, "origin") {
+		t.Errorf("expected parent to stay without child fields, got: %s", parentOutput)
+	}
+}
+
+func TestZeroLogger_WithFieldsNests(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log := NewWithWriter("development", buf).
+		WithFields(Field{Key: "origin", Value: "CGK"}).
+		WithFields(Field{Key: "err", Value: fmt.Errorf("search: %w", errors.New("timeout"))})
+
+	log.Warn("nested")
+
+	output := buf.String()
+	if !strings.Contains(output, `"origin":"CGK"`) || !strings.Contains(output, `"err":"search: timeout"`) {
+		t.Errorf("expected fields from both levels, got: %s", output)
+	}
+	if !strings.Contains(output, `"err_chain":["search: timeout","timeout"]`) {
+		t.Errorf("expected error chain for pre-set error, got: %s", output)
+	}
+}
@@ -0,0 +1,35 @@
+// Package synthetic tags traffic sent by synthetic monitoring, so it can be told apart in logs and traces
+// and kept out of analytics and SLO numbers.
+package synthetic
+
+import (
+	"context"
+	"travel/pkg/logger"
+)
+
+// Header marks a request as synthetic when set to a true value ("true", "1")
+const Header = "X-Synthetic"
+
+// LogKey is the attribute name used in logs and spans
+const LogKey = "synthetic"
+
+type ctxKey struct{}
+
+// WithTag returns a copy of ctx marked as synthetic
+func WithTag(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKey{}, true)
+}
+
+// FromContext reports whether ctx belongs to a synthetic request
+func FromContext(ctx context.Context) bool {
+	tagged, _ := ctx.Value(ctxKey{}).(bool)
+	return tagged
+}
+
+// LogField is synthetic=true for synthetic requests, and an empty Field (which loggers skip) otherwise
+func LogField(ctx context.Context) logger.Field {
+	if !FromContext(ctx) {
+		return logger.Field{}
+	}
+	return logger.Field{Key: LogKey, Value: true}
+}
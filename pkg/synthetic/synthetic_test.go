@@ -0,0 +1,32 @@
+package synthetic
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"travel/pkg/logger"
+)
+
+func TestLogField(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{name: "synthetic request", ctx: WithTag(context.Background()), want: true},
+		{name: "real request", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			log := logger.NewWithWriter("production", &buf)
+			log.Error("failed to fetch garuda", LogField(tt.ctx))
+
+			if got := strings.Contains(buf.String(), `"synthetic":true`); got != tt.want {
+				t.Errorf("expected synthetic in log=%v, got %s", tt.want, buf.String())
+			}
+		})
+	}
+}